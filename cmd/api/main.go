@@ -1,21 +1,126 @@
 package main
 
 import (
+	"errors"
 	"flag"
+	"fmt"
 	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/renatoaguimaraes/job-scheduler/internal/worker/api"
+	"github.com/renatoaguimaraes/job-scheduler/pkg/worker"
 	"github.com/renatoaguimaraes/job-scheduler/pkg/worker/conf"
 )
 
 func main() {
+	// runs the job init process when re-executed by the worker
+	worker.Init()
 	config := conf.NewConfig()
 	flag.StringVar(&config.ServerAddress, "host", "localhost:8080", "host:port")
 	flag.StringVar(&config.ClientCA, "ca", "cert/client-ca-cert.pem", "client ca path")
 	flag.StringVar(&config.ServerCertificate, "cert", "cert/server-cert.pem", "server cert path")
 	flag.StringVar(&config.ServerKey, "key", "cert/server-key.pem", "server key path")
+	flag.StringVar(&config.CgroupFolder, "cgroup", config.CgroupFolder, "cgroup v2 folder of the job control groups")
+	flag.StringVar(&config.JobStoreFile, "store", filepath.Join(config.LogFolder, "job-scheduler.journal"), "journal file of the jobs")
+	flag.BoolVar(&config.NamespaceIsolation, "isolation", false, "run jobs in new namespaces by default")
+	config.RunAs = make(map[string][]string)
+	flag.Var(runAsFlag(config.RunAs), "run-as", "users an identity can run jobs as, e.g. alice=backup,1000 or role:admin=* (repeatable)")
+	envAllowlist := flag.String("env-allowlist", strings.Join(config.EnvAllowlist, ","), "comma separated server environment variables passed to the jobs")
+	flag.BoolVar(&config.InheritEnv, "inherit-env", false, "pass the whole server environment to the jobs")
+	flag.IntVar(&config.MaxConcurrentJobs, "max-jobs", 0, "maximum number of running jobs, the next jobs are queued (default no limit)")
+	flag.IntVar(&config.LogBufferSize, "log-buffer", config.LogBufferSize, "records of a running job kept in memory for its streams, 0 follows the log files")
+	flag.StringVar(&config.LogWatcher, "log-watcher", config.LogWatcher, "how the log files are followed, inotify, poll or auto, which polls them if inotify fails")
+	flag.DurationVar(&config.LogPollInterval, "log-poll-interval", config.LogPollInterval, "interval to poll the log files")
+	flag.Var((*bytesFlag)(&config.LogRotateSize), "log-rotate-size", "size to rotate the log of a running job, e.g. 64M (default no rotation)")
+	flag.Var((*bytesFlag)(&config.LogMaxSize), "log-max-size", "maximum size of a job log, including the rotated files (default no limit)")
+	flag.StringVar(&config.LogLimitAction, "log-limit-action", config.LogLimitAction, "action when a job log reaches its maximum size, truncate or kill")
+	flag.DurationVar(&config.LogRetention, "log-retention", 0, "time to keep the logs of finished jobs (default forever)")
+	flag.DurationVar(&config.LogCompressAfter, "log-compress-after", 0, "time to compress the logs of finished jobs (default never)")
+	flag.Var((*bytesFlag)(&config.LogFolderBudget), "log-budget", "maximum total size of the job logs, the oldest logs of finished jobs are deleted (default no limit)")
+	flag.StringVar(&config.LogArchiveEndpoint, "log-archive-endpoint", "", "URL of the S3 compatible object storage to archive the logs of finished jobs (default no archive)")
+	flag.StringVar(&config.LogArchiveBucket, "log-archive-bucket", "", "bucket of the archived logs")
+	flag.StringVar(&config.LogArchivePrefix, "log-archive-prefix", "", "key prefix of the archived logs")
+	flag.StringVar(&config.LogArchiveRegion, "log-archive-region", config.LogArchiveRegion, "region of the object storage")
 	flag.Parse()
+	// the object storage credentials aren't passed as flags, so they don't leak
+	config.LogArchiveAccessKey = os.Getenv("AWS_ACCESS_KEY_ID")
+	config.LogArchiveSecretKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+	if config.LogLimitAction != conf.LogLimitTruncate && config.LogLimitAction != conf.LogLimitKill {
+		log.Fatalf("invalid log limit action %q", config.LogLimitAction)
+	}
+	switch config.LogWatcher {
+	case conf.LogWatcherAuto, conf.LogWatcherInotify, conf.LogWatcherPoll:
+	default:
+		log.Fatalf("invalid log watcher %q", config.LogWatcher)
+	}
+	if config.LogPollInterval <= 0 {
+		log.Fatalf("invalid log poll interval %v", config.LogPollInterval)
+	}
+	config.EnvAllowlist = splitList(*envAllowlist)
 	if err := api.StartServer(config); err != nil {
 		log.Fatalf("fail to start server, %v", err)
 	}
 }
+
+// splitList splits a comma separated list, the entries are trimmed and
+// the empty ones are dropped.
+func splitList(value string) []string {
+	var list []string
+	for _, entry := range strings.Split(value, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			list = append(list, entry)
+		}
+	}
+	return list
+}
+
+// runAsFlag repeatable flag of the users allowed by identity,
+// in the format identity=user[,user...].
+type runAsFlag map[string][]string
+
+func (f runAsFlag) String() string {
+	return fmt.Sprint(map[string][]string(f))
+}
+
+func (f runAsFlag) Set(value string) error {
+	parts := strings.SplitN(value, "=", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("invalid run as policy %q, expected identity=user[,user...]", value)
+	}
+	f[parts[0]] = append(f[parts[0]], strings.Split(parts[1], ",")...)
+	return nil
+}
+
+// bytesFlag size in bytes, with an optional K, M or G suffix.
+type bytesFlag int64
+
+func (f *bytesFlag) String() string {
+	return strconv.FormatInt(int64(*f), 10)
+}
+
+func (f *bytesFlag) Set(value string) error {
+	if value == "" {
+		return errors.New("empty size")
+	}
+	unit := int64(1)
+	switch strings.ToUpper(value[len(value)-1:]) {
+	case "K":
+		unit = 1 << 10
+	case "M":
+		unit = 1 << 20
+	case "G":
+		unit = 1 << 30
+	}
+	if unit > 1 {
+		value = value[:len(value)-1]
+	}
+	size, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || size < 0 {
+		return fmt.Errorf("invalid size %q", value)
+	}
+	*f = bytesFlag(size * unit)
+	return nil
+}
@@ -2,20 +2,127 @@ package api
 
 import (
 	"context"
+	"fmt"
+	"io"
+	logger "log"
 
 	"github.com/renatoaguimaraes/job-scheduler/internal/worker/proto"
 	"github.com/renatoaguimaraes/job-scheduler/pkg/worker"
+	"github.com/renatoaguimaraes/job-scheduler/pkg/worker/cgroup"
+	"github.com/renatoaguimaraes/job-scheduler/pkg/worker/log"
+	"github.com/renatoaguimaraes/job-scheduler/pkg/worker/scheduler"
+	"github.com/renatoaguimaraes/job-scheduler/pkg/worker/workflow"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// isolations maps the requested isolation to the worker isolation
+var isolations = map[proto.Isolation]worker.Isolation{
+	proto.Isolation_ISOLATION_DEFAULT:    worker.IsolationDefault,
+	proto.Isolation_ISOLATION_NONE:       worker.IsolationNone,
+	proto.Isolation_ISOLATION_NAMESPACES: worker.IsolationNamespaces,
+}
+
+// terminations maps the worker termination to the response termination
+var terminations = map[worker.Termination]proto.Termination{
+	worker.TerminationNone:    proto.Termination_TERMINATION_NONE,
+	worker.TerminationStopped: proto.Termination_TERMINATION_STOPPED,
+	worker.TerminationKilled:  proto.Termination_TERMINATION_KILLED,
+}
+
+// jobStates maps the worker job state to the response state, an unknown
+// state is sent as unspecified
+var jobStates = map[worker.State]proto.JobState{
+	worker.StatePending:   proto.JobState_JOB_PENDING,
+	worker.StateRunning:   proto.JobState_JOB_RUNNING,
+	worker.StateStopping:  proto.JobState_JOB_STOPPING,
+	worker.StateSucceeded: proto.JobState_JOB_SUCCEEDED,
+	worker.StateFailed:    proto.JobState_JOB_FAILED,
+	worker.StateKilled:    proto.JobState_JOB_KILLED,
+	worker.StateTimedOut:  proto.JobState_JOB_TIMED_OUT,
+	worker.StateLost:      proto.JobState_JOB_LOST,
+	worker.StatePaused:    proto.JobState_JOB_PAUSED,
+}
+
+// exitTerminations maps the logged termination to the response termination
+var exitTerminations = map[string]proto.Termination{
+	worker.TerminationStopped.String(): proto.Termination_TERMINATION_STOPPED,
+	worker.TerminationKilled.String():  proto.Termination_TERMINATION_KILLED,
+}
+
+// stateFilters maps the requested state filter to the worker state filter
+var stateFilters = map[proto.StateFilter]worker.StateFilter{
+	proto.StateFilter_STATE_FILTER_ALL:     worker.StateFilterAll,
+	proto.StateFilter_STATE_FILTER_RUNNING: worker.StateFilterRunning,
+	proto.StateFilter_STATE_FILTER_EXITED:  worker.StateFilterExited,
+	proto.StateFilter_STATE_FILTER_FAILED:  worker.StateFilterFailed,
+}
+
+// sources maps the requested output stream to the log sources
+var sources = map[proto.OutputStream][]log.Source{
+	proto.OutputStream_OUTPUT_STREAM_ALL:    {log.Stdout, log.Stderr},
+	proto.OutputStream_OUTPUT_STREAM_STDOUT: {log.Stdout},
+	proto.OutputStream_OUTPUT_STREAM_STDERR: {log.Stderr},
+}
+
+// outputStreams maps the log source to the response output stream
+var outputStreams = map[log.Source]proto.OutputStream{
+	log.Stdout: proto.OutputStream_OUTPUT_STREAM_STDOUT,
+	log.Stderr: proto.OutputStream_OUTPUT_STREAM_STDERR,
+}
+
+// policies maps the requested concurrency policy to the scheduler policy,
+// an unspecified policy is the scheduler default, see toPolicy
+var policies = map[proto.ConcurrencyPolicy]scheduler.ConcurrencyPolicy{
+	proto.ConcurrencyPolicy_CONCURRENCY_ALLOW:   scheduler.ConcurrencyAllow,
+	proto.ConcurrencyPolicy_CONCURRENCY_FORBID:  scheduler.ConcurrencyForbid,
+	proto.ConcurrencyPolicy_CONCURRENCY_REPLACE: scheduler.ConcurrencyReplace,
+}
+
+// conditions maps the requested step condition to the workflow condition
+var conditions = map[proto.StepCondition]workflow.Condition{
+	proto.StepCondition_STEP_CONDITION_SUCCESS: workflow.ConditionSuccess,
+	proto.StepCondition_STEP_CONDITION_FAILURE: workflow.ConditionFailure,
+	proto.StepCondition_STEP_CONDITION_ALWAYS:  workflow.ConditionAlways,
+}
+
+// workflowStates maps the workflow state to the response state, an unknown
+// state is sent as unspecified
+var workflowStates = map[workflow.State]proto.WorkflowState{
+	workflow.StateRunning:   proto.WorkflowState_WORKFLOW_RUNNING,
+	workflow.StateSucceeded: proto.WorkflowState_WORKFLOW_SUCCEEDED,
+	workflow.StateFailed:    proto.WorkflowState_WORKFLOW_FAILED,
+	workflow.StateCancelled: proto.WorkflowState_WORKFLOW_CANCELLED,
+}
+
+// stepStates maps the workflow step state to the response state, an unknown
+// state is sent as unspecified
+var stepStates = map[workflow.StepState]proto.StepState{
+	workflow.StepPending:   proto.StepState_STEP_PENDING,
+	workflow.StepRunning:   proto.StepState_STEP_RUNNING,
+	workflow.StepSucceeded: proto.StepState_STEP_SUCCEEDED,
+	workflow.StepFailed:    proto.StepState_STEP_FAILED,
+	workflow.StepSkipped:   proto.StepState_STEP_SKIPPED,
+	workflow.StepCancelled: proto.StepState_STEP_CANCELLED,
+}
+
 type workerServer struct {
 	proto.UnimplementedWorkerServiceServer
-	Worker worker.Worker
+	Worker    worker.Worker
+	Scheduler scheduler.Scheduler
+	Workflows workflow.Engine
+	// RunAs users allowed by identity, see conf.Config
+	RunAs map[string][]string
 }
 
 func (s *workerServer) Start(ctx context.Context, r *proto.StartRequest) (*proto.StartResponse, error) {
-	jobID, err := s.Worker.Start(worker.Command{Name: r.Name, Args: r.Args})
+	command := toCommand(r)
+	if err := s.checkRunAs(ctx, command); err != nil {
+		return nil, err
+	}
+	jobID, err := s.Worker.Start(command)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -26,42 +133,480 @@ func (s *workerServer) Start(ctx context.Context, r *proto.StartRequest) (*proto
 }
 
 func (s *workerServer) Stop(ctx context.Context, r *proto.StopRequest) (*proto.StopResponse, error) {
-	err := s.Worker.Stop(r.JobID)
+	options := worker.StopOptions{
+		GracePeriod: r.GracePeriod.AsDuration(),
+		Force:       r.Force,
+	}
+	err := s.Worker.Stop(r.JobID, options)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 	return &proto.StopResponse{}, nil
 }
 
+func (s *workerServer) Signal(ctx context.Context, r *proto.SignalRequest) (*proto.SignalResponse, error) {
+	sig, err := worker.ParseSignal(r.Signal)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.Worker.Signal(r.JobID, sig); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &proto.SignalResponse{}, nil
+}
+
+func (s *workerServer) Pause(ctx context.Context, r *proto.PauseRequest) (*proto.PauseResponse, error) {
+	if err := s.Worker.Pause(r.JobID); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &proto.PauseResponse{}, nil
+}
+
+func (s *workerServer) Resume(ctx context.Context, r *proto.ResumeRequest) (*proto.ResumeResponse, error) {
+	if err := s.Worker.Resume(r.JobID); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &proto.ResumeResponse{}, nil
+}
+
 func (s *workerServer) Query(ctx context.Context, r *proto.QueryRequest) (*proto.QueryResponse, error) {
 	jobstatus, err := s.Worker.Query(r.JobID)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 	res := proto.QueryResponse{
-		Pid:      int32(jobstatus.Pid),
-		ExitCode: int32(jobstatus.ExitCode),
-		Exited:   jobstatus.Exited,
+		Pid:           int32(jobstatus.Pid),
+		ExitCode:      int32(jobstatus.ExitCode),
+		Exited:        jobstatus.Exited,
+		Termination:   terminations[jobstatus.Termination],
+		Lost:          jobstatus.Lost,
+		TimedOut:      jobstatus.TimedOut,
+		Attempt:       int32(jobstatus.Attempt),
+		Queued:        jobstatus.Queued,
+		QueuePosition: int32(jobstatus.QueuePosition),
+		Signal:        worker.SignalName(jobstatus.Signal),
+		CoreDumped:    jobstatus.CoreDumped,
+		StartTime:     timestamppb.New(jobstatus.StartTime),
+		Duration:      durationpb.New(jobstatus.Duration),
+		Usage:         resourceUsage(jobstatus.Usage),
+		State:         jobStates[jobstatus.State],
+	}
+	if !jobstatus.EndTime.IsZero() {
+		res.EndTime = timestamppb.New(jobstatus.EndTime)
+	}
+	for _, attempt := range jobstatus.Attempts {
+		res.Attempts = append(res.Attempts, &proto.Attempt{
+			Pid:        int32(attempt.Pid),
+			ExitCode:   int32(attempt.ExitCode),
+			Exited:     attempt.Exited,
+			StartTime:  timestamppb.New(attempt.StartTime),
+			EndTime:    timestamppb.New(attempt.EndTime),
+			Signal:     worker.SignalName(attempt.Signal),
+			CoreDumped: attempt.CoreDumped,
+			Usage:      resourceUsage(attempt.Usage),
+		})
 	}
 	return &res, nil
 }
 
+// resourceUsage maps the resources used by a job to the response usage.
+func resourceUsage(usage worker.Usage) *proto.ResourceUsage {
+	return &proto.ResourceUsage{
+		UserTime:   durationpb.New(usage.UserTime),
+		SystemTime: durationpb.New(usage.SystemTime),
+		MaxRss:     usage.MaxRSS,
+		InBlock:    usage.InBlock,
+		OutBlock:   usage.OutBlock,
+	}
+}
+
 func (s *workerServer) Stream(r *proto.StreamRequest, stream proto.WorkerService_StreamServer) error {
-	logchan, err := s.Worker.Stream(stream.Context(), r.JobID)
+	if r.Offset < 0 || r.Tail < 0 {
+		return status.Error(codes.InvalidArgument, "the offset and tail must not be negative")
+	}
+	options := log.StreamOptions{
+		Sources:  sources[r.Stream],
+		Offset:   r.Offset,
+		Tail:     int(r.Tail),
+		NoFollow: r.NoFollow,
+	}
+	if r.Since != nil {
+		options.Since = r.Since.AsTime()
+	}
+	logchan, err := s.Worker.Stream(stream.Context(), r.JobID, options)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case output, ok := <-logchan:
+			if !ok {
+				return nil
+			}
+			res := proto.StreamResponse{
+				Output: output.Data,
+				Stream: outputStreams[output.Source],
+				Seq:    output.Seq,
+				Time:   timestamppb.New(output.Time),
+				Offset: output.Offset,
+			}
+			if output.Exit != nil {
+				res.Exit = &proto.ExitStatus{
+					ExitCode:    int32(output.Exit.Code),
+					Exited:      output.Exit.Exited,
+					Termination: exitTerminations[output.Exit.Termination],
+					TimedOut:    output.Exit.TimedOut,
+					Signal:      output.Exit.Signal,
+				}
+			}
+			if err := stream.SendMsg(&res); err != nil {
+				return status.Error(codes.Internal, err.Error())
+			}
+		}
+	}
+}
+
+// Attach forwards the client input to the job stdin, and streams the job output.
+// The first request carries the job ID. The end of the client input closes the
+// job stdin, while a cancelled stream just detaches. The window size changes
+// resize the job pseudo-terminal.
+func (s *workerServer) Attach(stream proto.WorkerService_AttachServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	jobID := req.JobID
+	stdin, logchan, err := s.Worker.Attach(stream.Context(), jobID)
 	if err != nil {
 		return status.Error(codes.Internal, err.Error())
 	}
+	go func(req *proto.AttachRequest) {
+		for {
+			if req.Resize != nil {
+				size := worker.WindowSize{Rows: uint16(req.Resize.Rows), Cols: uint16(req.Resize.Cols)}
+				if err := s.Worker.Resize(jobID, size); err != nil {
+					logger.Printf("Fail to resize the job terminal, %v", err)
+				}
+			}
+			if len(req.Input) > 0 {
+				if _, err := stdin.Write(req.Input); err != nil {
+					logger.Printf("Fail to write the job stdin, %v", err)
+					return
+				}
+			}
+			next, err := stream.Recv()
+			if err != nil {
+				if err == io.EOF {
+					stdin.Close()
+				}
+				return
+			}
+			req = next
+		}
+	}(req)
 	for {
 		select {
 		case <-stream.Context().Done():
 			return stream.Context().Err()
-		case log, ok := <-logchan:
+		case output, ok := <-logchan:
 			if !ok {
 				return nil
 			}
-			if err := stream.SendMsg(&proto.StreamResponse{Output: log}); err != nil {
+			// the exit status isn't part of the output
+			if output.Exit != nil {
+				continue
+			}
+			if err := stream.Send(&proto.AttachResponse{Output: output.Data, Stream: outputStreams[output.Source]}); err != nil {
 				return status.Error(codes.Internal, err.Error())
 			}
 		}
 	}
 }
+
+func (s *workerServer) List(ctx context.Context, r *proto.ListRequest) (*proto.ListResponse, error) {
+	options := worker.ListOptions{
+		State:     stateFilters[r.State],
+		Name:      r.Name,
+		PageSize:  int(r.PageSize),
+		PageToken: r.PageToken,
+	}
+	if r.StartedAfter != nil {
+		options.StartedAfter = r.StartedAfter.AsTime()
+	}
+	if r.StartedBefore != nil {
+		options.StartedBefore = r.StartedBefore.AsTime()
+	}
+	jobs, next, err := s.Worker.List(options)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	res := proto.ListResponse{NextPageToken: next}
+	for _, job := range jobs {
+		res.Jobs = append(res.Jobs, &proto.JobSummary{
+			JobID:       job.ID,
+			Name:        job.Command.Name,
+			Args:        job.Command.Args,
+			Pid:         int32(job.Status.Pid),
+			ExitCode:    int32(job.Status.ExitCode),
+			Exited:      job.Status.Exited,
+			Termination: terminations[job.Status.Termination],
+			Lost:        job.Status.Lost,
+			StartTime:   timestamppb.New(job.Status.StartTime),
+			TimedOut:    job.Status.TimedOut,
+			Queued:      job.Status.Queued,
+			State:       jobStates[job.Status.State],
+		})
+	}
+	return &res, nil
+}
+
+func (s *workerServer) CreateSchedule(ctx context.Context, r *proto.CreateScheduleRequest) (*proto.CreateScheduleResponse, error) {
+	if r.Command == nil {
+		return nil, status.Error(codes.InvalidArgument, "you must pass a command")
+	}
+	spec := scheduler.Spec{
+		Cron:     r.Cron,
+		TimeZone: r.TimeZone,
+		Command:  toCommand(r.Command),
+	}
+	var err error
+	if spec.Policy, err = toPolicy(r.Policy); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.checkRunAs(ctx, spec.Command); err != nil {
+		return nil, err
+	}
+	scheduleID, err := s.Scheduler.Create(spec)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &proto.CreateScheduleResponse{ScheduleID: scheduleID}, nil
+}
+
+func (s *workerServer) DeleteSchedule(ctx context.Context, r *proto.DeleteScheduleRequest) (*proto.DeleteScheduleResponse, error) {
+	if err := s.Scheduler.Delete(r.ScheduleID); err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return &proto.DeleteScheduleResponse{}, nil
+}
+
+func (s *workerServer) ListSchedules(ctx context.Context, r *proto.ListSchedulesRequest) (*proto.ListSchedulesResponse, error) {
+	var res proto.ListSchedulesResponse
+	for _, sc := range s.Scheduler.List() {
+		schedule := &proto.Schedule{
+			ScheduleID: sc.ID,
+			Cron:       sc.Spec.Cron,
+			TimeZone:   sc.Spec.TimeZone,
+			Command:    fromCommand(sc.Spec.Command),
+			JobIDs:     sc.JobIDs,
+		}
+		for policy, value := range policies {
+			if value == sc.Spec.Policy {
+				schedule.Policy = policy
+			}
+		}
+		if !sc.NextRun.IsZero() {
+			schedule.NextRun = timestamppb.New(sc.NextRun)
+		}
+		res.Schedules = append(res.Schedules, schedule)
+	}
+	return &res, nil
+}
+
+func (s *workerServer) CreateWorkflow(ctx context.Context, r *proto.CreateWorkflowRequest) (*proto.CreateWorkflowResponse, error) {
+	spec := workflow.Spec{Name: r.Name}
+	for _, step := range r.Steps {
+		if step.Command == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "you must pass a command to the step %q", step.Name)
+		}
+		st := workflow.Step{
+			Name:    step.Name,
+			Command: toCommand(step.Command),
+		}
+		if err := s.checkRunAs(ctx, st.Command); err != nil {
+			return nil, err
+		}
+		for _, dep := range step.After {
+			st.After = append(st.After, workflow.Dependency{Step: dep.Step, Condition: conditions[dep.Condition]})
+		}
+		spec.Steps = append(spec.Steps, st)
+	}
+	workflowID, err := s.Workflows.Create(spec)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &proto.CreateWorkflowResponse{WorkflowID: workflowID}, nil
+}
+
+func (s *workerServer) StopWorkflow(ctx context.Context, r *proto.StopWorkflowRequest) (*proto.StopWorkflowResponse, error) {
+	if err := s.Workflows.Stop(r.WorkflowID); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &proto.StopWorkflowResponse{}, nil
+}
+
+func (s *workerServer) QueryWorkflow(ctx context.Context, r *proto.QueryWorkflowRequest) (*proto.QueryWorkflowResponse, error) {
+	wf, err := s.Workflows.Query(r.WorkflowID)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return &proto.QueryWorkflowResponse{Workflow: fromWorkflow(wf)}, nil
+}
+
+func (s *workerServer) ListWorkflows(ctx context.Context, r *proto.ListWorkflowsRequest) (*proto.ListWorkflowsResponse, error) {
+	var res proto.ListWorkflowsResponse
+	for _, wf := range s.Workflows.List() {
+		res.Workflows = append(res.Workflows, fromWorkflow(wf))
+	}
+	return &res, nil
+}
+
+// checkRunAs verifies if the authorized user can run the command as the requested user.
+func (s *workerServer) checkRunAs(ctx context.Context, command worker.Command) error {
+	if command.User == "" {
+		return nil
+	}
+	id, _ := ctx.Value(identityKey{}).(identity)
+	if !CanRunAs(s.RunAs, id.identities(), command.User) {
+		return status.Errorf(codes.PermissionDenied, "unauthorized to run jobs as %v", command.User)
+	}
+	return nil
+}
+
+// fromWorkflow converts a workflow snapshot to the response format.
+func fromWorkflow(wf workflow.Workflow) *proto.Workflow {
+	res := &proto.Workflow{
+		WorkflowID: wf.ID,
+		Name:       wf.Spec.Name,
+		State:      workflowStates[wf.State],
+	}
+	for _, step := range wf.Steps {
+		res.Steps = append(res.Steps, &proto.StepStatus{
+			Name:     step.Name,
+			State:    stepStates[step.State],
+			JobID:    step.JobID,
+			ExitCode: int32(step.Status.ExitCode),
+			Error:    step.Error,
+		})
+	}
+	return res
+}
+
+// toCommand converts the requested command.
+func toCommand(r *proto.StartRequest) worker.Command {
+	command := worker.Command{
+		Name:        r.Name,
+		Args:        r.Args,
+		Limits:      toLimits(r.Limits),
+		Isolation:   isolations[r.Isolation],
+		Timeout:     r.Timeout.AsDuration(),
+		Retry:       toRetryPolicy(r.Retry),
+		Priority:    int(r.Priority),
+		Env:         r.Env,
+		Dir:         r.Dir,
+		User:        r.User,
+		Interactive: r.Interactive,
+		TTY:         r.Tty,
+	}
+	if r.Deadline != nil {
+		command.Deadline = r.Deadline.AsTime()
+	}
+	return command
+}
+
+// toPolicy converts the requested concurrency policy, an unspecified
+// policy allows the overlapping runs, like the scheduler default.
+func toPolicy(policy proto.ConcurrencyPolicy) (scheduler.ConcurrencyPolicy, error) {
+	if policy == proto.ConcurrencyPolicy_CONCURRENCY_POLICY_UNSPECIFIED {
+		return scheduler.ConcurrencyAllow, nil
+	}
+	p, ok := policies[policy]
+	if !ok {
+		return 0, fmt.Errorf("invalid concurrency policy %v", policy)
+	}
+	return p, nil
+}
+
+// toRetryPolicy converts the requested retry policy.
+func toRetryPolicy(r *proto.RetryPolicy) worker.RetryPolicy {
+	if r == nil {
+		return worker.RetryPolicy{}
+	}
+	policy := worker.RetryPolicy{
+		MaxAttempts: int(r.MaxAttempts),
+		Backoff:     r.Backoff.AsDuration(),
+		MaxBackoff:  r.MaxBackoff.AsDuration(),
+		Jitter:      r.Jitter,
+	}
+	for _, code := range r.ExitCodes {
+		policy.ExitCodes = append(policy.ExitCodes, int(code))
+	}
+	return policy
+}
+
+// fromCommand converts a worker command to the request format.
+func fromCommand(command worker.Command) *proto.StartRequest {
+	r := &proto.StartRequest{
+		Name:        command.Name,
+		Args:        command.Args,
+		Priority:    int32(command.Priority),
+		Env:         command.Env,
+		Dir:         command.Dir,
+		User:        command.User,
+		Interactive: command.Interactive,
+		Tty:         command.TTY,
+	}
+	if command.Timeout > 0 {
+		r.Timeout = durationpb.New(command.Timeout)
+	}
+	if !command.Deadline.IsZero() {
+		r.Deadline = timestamppb.New(command.Deadline)
+	}
+	for isolation, value := range isolations {
+		if value == command.Isolation {
+			r.Isolation = isolation
+		}
+	}
+	if command.Retry.MaxAttempts > 0 {
+		r.Retry = &proto.RetryPolicy{
+			MaxAttempts: int32(command.Retry.MaxAttempts),
+			Backoff:     durationpb.New(command.Retry.Backoff),
+			MaxBackoff:  durationpb.New(command.Retry.MaxBackoff),
+			Jitter:      command.Retry.Jitter,
+		}
+		for _, code := range command.Retry.ExitCodes {
+			r.Retry.ExitCodes = append(r.Retry.ExitCodes, int32(code))
+		}
+	}
+	if !command.Limits.IsZero() {
+		r.Limits = &proto.ResourceLimits{
+			CpuWeight: command.Limits.CPUWeight,
+			CpuQuota:  command.Limits.CPUQuota,
+			CpuPeriod: command.Limits.CPUPeriod,
+			MemoryMax: command.Limits.MemoryMax,
+		}
+		for _, io := range command.Limits.IO {
+			r.Limits.Io = append(r.Limits.Io, &proto.IOLimit{Device: io.Device, ReadBPS: io.ReadBPS, WriteBPS: io.WriteBPS})
+		}
+	}
+	return r
+}
+
+// toLimits converts the requested resource limits.
+func toLimits(r *proto.ResourceLimits) cgroup.Limits {
+	if r == nil {
+		return cgroup.Limits{}
+	}
+	limits := cgroup.Limits{
+		CPUWeight: r.CpuWeight,
+		CPUQuota:  r.CpuQuota,
+		CPUPeriod: r.CpuPeriod,
+		MemoryMax: r.MemoryMax,
+	}
+	for _, io := range r.Io {
+		limits.IO = append(limits.IO, cgroup.IOLimit{Device: io.Device, ReadBPS: io.ReadBPS, WriteBPS: io.WriteBPS})
+	}
+	return limits
+}
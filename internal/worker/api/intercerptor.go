@@ -14,38 +14,61 @@ import (
 // UnaryAuthInterceptor intercept unary calls to authorize the user
 // based on certification extension oid 1.2.840.10070.8.1.
 func UnaryAuthInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-	if err := authorize(ctx, info.FullMethod); err != nil {
+	id, err := authorize(ctx, info.FullMethod)
+	if err != nil {
 		return nil, status.Error(codes.PermissionDenied, err.Error())
 	}
-	return handler(ctx, req)
+	return handler(context.WithValue(ctx, identityKey{}, id), req)
 }
 
 // StreamAuthInterceptor intercept stream calls to authorize the user
 // based on certification extension oid 1.2.840.10070.8.1.
 func StreamAuthInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-	if err := authorize(stream.Context(), info.FullMethod); err != nil {
+	if _, err := authorize(stream.Context(), info.FullMethod); err != nil {
 		return status.Error(codes.PermissionDenied, err.Error())
 	}
 	return handler(srv, stream)
 }
 
+// identity of an authorized user.
+type identity struct {
+	// name common name of the certificate
+	name string
+	// roles of the certificate
+	roles []string
+}
+
+// identities returns the common name and the roles in
+// the format role:<role>, see the RunAs configuration.
+func (i identity) identities() []string {
+	identities := []string{i.name}
+	for _, role := range i.roles {
+		identities = append(identities, "role:"+role)
+	}
+	return identities
+}
+
+// identityKey context key of the authorized user identity.
+type identityKey struct{}
+
 // authorize verifies the user information given by certificate
 // against the mapped roles for a specific method.
-func authorize(ctx context.Context, method string) error {
+// It returns the identity of the authorized user.
+func authorize(ctx context.Context, method string) (identity, error) {
 	// reads the peer information from context
 	peer, ok := peer.FromContext(ctx)
 	if !ok {
-		return errors.New("error to read peer information")
+		return identity{}, errors.New("error to read peer information")
 	}
 	// reads user tls inforation
 	tlsInfo, ok := peer.AuthInfo.(credentials.TLSInfo)
 	if !ok {
-		return errors.New("error to get auth information")
+		return identity{}, errors.New("error to get auth information")
 	}
 	// access the leaf certificate to get user roles
 	certs := tlsInfo.State.VerifiedChains
 	if len(certs) == 0 || len(certs[0]) == 0 {
-		return errors.New("missing certificate chain")
+		return identity{}, errors.New("missing certificate chain")
 	}
 	// find user roles from certificate extensions
 	var roles []string
@@ -57,7 +80,7 @@ func authorize(ctx context.Context, method string) error {
 	}
 	// check user permissions to execute a specific method
 	if !HasPermission(method, roles) {
-		return errors.New("unauthorized, user does not have privileges enough")
+		return identity{}, errors.New("unauthorized, user does not have privileges enough")
 	}
-	return nil
+	return identity{name: certs[0][0].Subject.CommonName, roles: roles}, nil
 }
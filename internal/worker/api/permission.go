@@ -12,8 +12,22 @@ const oidRole string = "1.2.840.10070.8.1"
 var permissions = map[string][]string{
 	"/WorkerService/Start":  {"admin"},
 	"/WorkerService/Stop":   {"admin"},
+	"/WorkerService/Signal": {"admin"},
+	"/WorkerService/Pause":  {"admin"},
+	"/WorkerService/Resume": {"admin"},
 	"/WorkerService/Query":  {"admin", "user"},
 	"/WorkerService/Stream": {"admin", "user"},
+	"/WorkerService/Attach": {"admin"},
+	"/WorkerService/List":   {"admin", "user"},
+
+	"/WorkerService/CreateSchedule": {"admin"},
+	"/WorkerService/DeleteSchedule": {"admin"},
+	"/WorkerService/ListSchedules":  {"admin", "user"},
+
+	"/WorkerService/CreateWorkflow": {"admin"},
+	"/WorkerService/StopWorkflow":   {"admin"},
+	"/WorkerService/QueryWorkflow":  {"admin", "user"},
+	"/WorkerService/ListWorkflows":  {"admin", "user"},
 }
 
 // HasPermission verifies the permission given a method and user roles
@@ -32,6 +46,19 @@ func HasPermission(method string, roles []string) bool {
 	return false
 }
 
+// CanRunAs verifies if any of the identities is allowed to run jobs as
+// the given user, see the RunAs configuration.
+func CanRunAs(policy map[string][]string, identities []string, user string) bool {
+	for _, id := range identities {
+		for _, allowed := range policy[id] {
+			if allowed == "*" || allowed == user {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // IsOidRole validates the role oid
 func IsOidRole(oid string) bool {
 	return oidRole == oid
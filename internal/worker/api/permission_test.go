@@ -65,3 +65,23 @@ func TestIsOidRoleInvalid(t *testing.T) {
 
 	assert.False(t, isrole)
 }
+
+func TestHasPermissionListUser(t *testing.T) {
+	permitted := HasPermission("/WorkerService/List", []string{"user"})
+
+	assert.True(t, permitted)
+}
+
+func TestCanRunAs(t *testing.T) {
+	policy := map[string][]string{
+		"alice":      {"backup", "1000"},
+		"role:admin": {"*"},
+	}
+
+	assert.True(t, CanRunAs(policy, []string{"alice", "role:user"}, "backup"))
+	assert.True(t, CanRunAs(policy, []string{"alice"}, "1000"))
+	assert.False(t, CanRunAs(policy, []string{"alice"}, "root"))
+	assert.True(t, CanRunAs(policy, []string{"bob", "role:admin"}, "root"))
+	assert.False(t, CanRunAs(policy, []string{"bob", "role:user"}, "backup"))
+	assert.False(t, CanRunAs(nil, []string{"alice"}, "backup"))
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/renatoaguimaraes/job-scheduler/internal/worker/proto"
 	"github.com/renatoaguimaraes/job-scheduler/pkg/worker"
 	"github.com/renatoaguimaraes/job-scheduler/pkg/worker/conf"
+	"github.com/renatoaguimaraes/job-scheduler/pkg/worker/scheduler"
+	"github.com/renatoaguimaraes/job-scheduler/pkg/worker/workflow"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 )
@@ -41,6 +43,10 @@ func loadTLSCredentials(conf conf.Config) (credentials.TransportCredentials, err
 }
 
 func createServer(conf conf.Config, cred credentials.TransportCredentials) (*grpc.Server, net.Listener, error) {
+	w, err := worker.NewWorker(conf)
+	if err != nil {
+		return nil, nil, err
+	}
 	lis, err := net.Listen("tcp", conf.ServerAddress)
 	if err != nil {
 		return nil, nil, err
@@ -51,7 +57,10 @@ func createServer(conf conf.Config, cred credentials.TransportCredentials) (*grp
 		grpc.StreamInterceptor(StreamAuthInterceptor),
 	)
 	proto.RegisterWorkerServiceServer(grpcServer, &workerServer{
-		Worker: worker.NewWorker(conf),
+		Worker:    w,
+		Scheduler: scheduler.NewScheduler(w),
+		Workflows: workflow.NewEngine(w),
+		RunAs:     conf.RunAs,
 	})
 	return grpcServer, lis, nil
 }
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/renatoaguimaraes/job-scheduler/internal/worker/proto"
+	"github.com/renatoaguimaraes/job-scheduler/pkg/worker"
 	"github.com/renatoaguimaraes/job-scheduler/pkg/worker/conf"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -21,6 +22,11 @@ import (
 
 var config = conf.Config{ServerAddress: "localhost:8080", LogFolder: os.TempDir()}
 
+func TestMain(m *testing.M) {
+	worker.Init()
+	os.Exit(m.Run())
+}
+
 func TestStartAuthnAuthzAdminUser(t *testing.T) {
 	// creates server
 	serv := createTestServer(t, clientca, servercert, serverkey)
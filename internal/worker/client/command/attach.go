@@ -0,0 +1,139 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+
+	"github.com/renatoaguimaraes/job-scheduler/internal/worker/proto"
+	"google.golang.org/grpc"
+)
+
+// detachKey detaches from a job with terminal, Ctrl-]
+const detachKey = 0x1d
+
+type AttachCommand struct {
+	client proto.WorkerServiceClient
+}
+
+func NewAttachCommand(client proto.WorkerServiceClient) Runner {
+	return &AttachCommand{
+		client: client,
+	}
+}
+
+// Run forwards the local stdin to an interactive job and writes the job output
+// to the local stdout. The end of the local input closes the job stdin, and an
+// interrupt detaches from the job. With a job terminal, the local terminal is
+// in raw mode, its window size is sent to the job, and Ctrl-] detaches.
+func (c *AttachCommand) Run(args []string) error {
+	flags := flag.NewFlagSet("attach", flag.ContinueOnError)
+	tty := flags.Bool("tty", false, "attach the local terminal to the job terminal")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() < 1 {
+		return errors.New("you must pass an argument")
+	}
+	first := &proto.AttachRequest{JobID: flags.Arg(0)}
+	stdinFd := int(os.Stdin.Fd())
+	if *tty {
+		if !isTerminal(stdinFd) {
+			return errors.New("the stdin isn't a terminal")
+		}
+		restore, err := makeRaw(stdinFd)
+		if err != nil {
+			return err
+		}
+		defer restore()
+		if rows, cols, err := windowSize(stdinFd); err == nil {
+			first.Resize = &proto.WindowSize{Rows: rows, Cols: cols}
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := c.client.Attach(ctx, grpc.WaitForReady(true))
+	if err != nil {
+		return err
+	}
+	// the stdin and the window size changes are sent concurrently
+	var mtx sync.Mutex
+	send := func(req *proto.AttachRequest) error {
+		mtx.Lock()
+		defer mtx.Unlock()
+		return stream.Send(req)
+	}
+	if err := send(first); err != nil {
+		return err
+	}
+	// forwards the stdin in background
+	go func() {
+		buf := make([]byte, 1024)
+		for {
+			nbytes, err := os.Stdin.Read(buf)
+			input := buf[:nbytes]
+			if *tty {
+				if i := bytes.IndexByte(input, detachKey); i >= 0 {
+					input = input[:i]
+					err = errDetached
+				}
+			}
+			if len(input) > 0 {
+				input = append([]byte(nil), input...)
+				if err := send(&proto.AttachRequest{Input: input}); err != nil {
+					return
+				}
+			}
+			if err == io.EOF {
+				mtx.Lock()
+				stream.CloseSend()
+				mtx.Unlock()
+			}
+			if err == errDetached {
+				cancel()
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	// sends the window size changes in background
+	if *tty {
+		winchan := make(chan os.Signal, 1)
+		notifyResize(winchan)
+		defer signal.Stop(winchan)
+		go func() {
+			for range winchan {
+				if rows, cols, err := windowSize(stdinFd); err == nil {
+					send(&proto.AttachRequest{Resize: &proto.WindowSize{Rows: rows, Cols: cols}})
+				}
+			}
+		}()
+	}
+	// waits for os signal to detach
+	sigchan := make(chan os.Signal, 1)
+	signal.Notify(sigchan, os.Interrupt)
+	defer signal.Stop(sigchan)
+	go func() {
+		<-sigchan
+		cancel()
+	}()
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF || ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		writeOutput(res.Stream, res.Output)
+	}
+}
+
+// errDetached is the end of the input when the detach key is typed
+var errDetached = errors.New("detached")
@@ -22,10 +22,17 @@ func Execute(config conf.Config, args []string) error {
 		return err
 	}
 	cmds := map[string]Runner{
-		"start":  NewStartCommand(client),
-		"query":  NewQueryCommand(client),
-		"stop":   NewStopCommand(client),
-		"stream": NewStreamCommand(client),
+		"start":    NewStartCommand(client),
+		"query":    NewQueryCommand(client),
+		"stop":     NewStopCommand(client),
+		"signal":   NewSignalCommand(client),
+		"pause":    NewPauseCommand(client),
+		"resume":   NewResumeCommand(client),
+		"stream":   NewStreamCommand(client),
+		"attach":   NewAttachCommand(client),
+		"list":     NewListCommand(client),
+		"schedule": NewScheduleCommand(client),
+		"workflow": NewWorkflowCommand(client),
 	}
 	cmd, ok := cmds[args[0]]
 	if ok {
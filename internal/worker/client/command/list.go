@@ -0,0 +1,100 @@
+package command
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/renatoaguimaraes/job-scheduler/internal/worker/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// stateFilters available by flag value
+var stateFilters = map[string]proto.StateFilter{
+	"":        proto.StateFilter_STATE_FILTER_ALL,
+	"running": proto.StateFilter_STATE_FILTER_RUNNING,
+	"exited":  proto.StateFilter_STATE_FILTER_EXITED,
+	"failed":  proto.StateFilter_STATE_FILTER_FAILED,
+}
+
+type ListCommand struct {
+	client proto.WorkerServiceClient
+}
+
+func NewListCommand(client proto.WorkerServiceClient) Runner {
+	return &ListCommand{
+		client: client,
+	}
+}
+
+func (c *ListCommand) Run(args []string) error {
+	flags := flag.NewFlagSet("list", flag.ContinueOnError)
+	state := flags.String("state", "", "job state, running, exited or failed")
+	name := flags.String("name", "", "command name")
+	after := flags.String("after", "", "jobs started at or after the time, in RFC 3339 format")
+	before := flags.String("before", "", "jobs started before the time, in RFC 3339 format")
+	pageSize := flags.Int("page-size", 0, "maximum number of jobs (default server page size)")
+	pageToken := flags.String("page-token", "", "token of the page to list")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	filter, ok := stateFilters[*state]
+	if !ok {
+		return fmt.Errorf("invalid state %q", *state)
+	}
+	command := proto.ListRequest{
+		State:     filter,
+		Name:      *name,
+		PageSize:  int32(*pageSize),
+		PageToken: *pageToken,
+	}
+	var err error
+	if command.StartedAfter, err = parseTimestamp(*after); err != nil {
+		return err
+	}
+	if command.StartedBefore, err = parseTimestamp(*before); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	res, err := c.client.List(ctx, &command, grpc.WaitForReady(true))
+	if err != nil {
+		return err
+	}
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "JOB ID\tSTATE\tPID\tEXIT CODE\tSTARTED\tCOMMAND")
+	for _, job := range res.Jobs {
+		fmt.Fprintf(table, "%s\t%s\t%d\t%d\t%s\t%s\n",
+			job.JobID,
+			jobStates[job.State],
+			job.Pid,
+			job.ExitCode,
+			job.StartTime.AsTime().Local().Format(time.RFC3339),
+			strings.Join(append([]string{job.Name}, job.Args...), " "),
+		)
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+	if res.NextPageToken != "" {
+		os.Stdout.WriteString(fmt.Sprintf("Next page: --page-token %s\n", res.NextPageToken))
+	}
+	return nil
+}
+
+// parseTimestamp parses an optional RFC 3339 time.
+func parseTimestamp(value string) (*timestamppb.Timestamp, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return timestamppb.New(t), nil
+}
@@ -0,0 +1,66 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/renatoaguimaraes/job-scheduler/internal/worker/proto"
+	"google.golang.org/grpc"
+)
+
+type PauseCommand struct {
+	client proto.WorkerServiceClient
+}
+
+func NewPauseCommand(client proto.WorkerServiceClient) Runner {
+	return &PauseCommand{
+		client: client,
+	}
+}
+
+func (c *PauseCommand) Run(args []string) error {
+	if len(args) < 1 {
+		return errors.New("you must pass an argument")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	command := proto.PauseRequest{
+		JobID: args[0],
+	}
+	_, err := c.client.Pause(ctx, &command, grpc.WaitForReady(true))
+	if err != nil {
+		return err
+	}
+	os.Stdout.WriteString(fmt.Sprintf("Job %v has been paused\n", command.JobID))
+	return nil
+}
+
+type ResumeCommand struct {
+	client proto.WorkerServiceClient
+}
+
+func NewResumeCommand(client proto.WorkerServiceClient) Runner {
+	return &ResumeCommand{
+		client: client,
+	}
+}
+
+func (c *ResumeCommand) Run(args []string) error {
+	if len(args) < 1 {
+		return errors.New("you must pass an argument")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	command := proto.ResumeRequest{
+		JobID: args[0],
+	}
+	_, err := c.client.Resume(ctx, &command, grpc.WaitForReady(true))
+	if err != nil {
+		return err
+	}
+	os.Stdout.WriteString(fmt.Sprintf("Job %v has been resumed\n", command.JobID))
+	return nil
+}
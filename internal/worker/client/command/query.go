@@ -11,6 +11,27 @@ import (
 	"google.golang.org/grpc"
 )
 
+// terminations names by termination
+var terminations = map[proto.Termination]string{
+	proto.Termination_TERMINATION_NONE:    "none",
+	proto.Termination_TERMINATION_STOPPED: "stopped",
+	proto.Termination_TERMINATION_KILLED:  "killed",
+}
+
+// jobStates names by job state
+var jobStates = map[proto.JobState]string{
+	proto.JobState_JOB_STATE_UNSPECIFIED: "unknown",
+	proto.JobState_JOB_PENDING:           "pending",
+	proto.JobState_JOB_RUNNING:           "running",
+	proto.JobState_JOB_STOPPING:          "stopping",
+	proto.JobState_JOB_SUCCEEDED:         "succeeded",
+	proto.JobState_JOB_FAILED:            "failed",
+	proto.JobState_JOB_KILLED:            "killed",
+	proto.JobState_JOB_TIMED_OUT:         "timed_out",
+	proto.JobState_JOB_LOST:              "lost",
+	proto.JobState_JOB_PAUSED:            "paused",
+}
+
 type QueryCommand struct {
 	client proto.WorkerServiceClient
 }
@@ -34,6 +55,55 @@ func (c *QueryCommand) Run(args []string) error {
 	if err != nil {
 		return err
 	}
-	os.Stdout.WriteString(fmt.Sprintf("Pid: %v Exit code: %v Exited: %v\n", res.Pid, res.ExitCode, res.Exited))
+	os.Stdout.WriteString(fmt.Sprintf("State: %v\n", jobStates[res.State]))
+	os.Stdout.WriteString(fmt.Sprintf("Pid: %v Exit code: %v Exited: %v Termination: %v Lost: %v Timed out: %v Attempt: %v\n", res.Pid, res.ExitCode, res.Exited, terminations[res.Termination], res.Lost, res.TimedOut, res.Attempt))
+	if res.Queued {
+		os.Stdout.WriteString(fmt.Sprintf("Queued at position %v\n", res.QueuePosition))
+	}
+	if res.Signal != "" {
+		os.Stdout.WriteString(fmt.Sprintf("Signal: %v Core dumped: %v\n", res.Signal, res.CoreDumped))
+	}
+	finished := "-"
+	if res.EndTime != nil {
+		finished = res.EndTime.AsTime().Local().Format(time.RFC3339)
+	}
+	os.Stdout.WriteString(fmt.Sprintf("Started: %v Finished: %v Duration: %v\n", res.StartTime.AsTime().Local().Format(time.RFC3339), finished,
+		res.Duration.AsDuration().Round(time.Millisecond)))
+	os.Stdout.WriteString(formatUsage(res.Usage) + "\n")
+	if res.Attempt > 1 {
+		for i, attempt := range res.Attempts {
+			os.Stdout.WriteString(fmt.Sprintf("Attempt %v: Pid: %v Exit code: %v Exited: %v Signal: %v Started: %v Finished: %v %v\n", i+1, attempt.Pid, attempt.ExitCode, attempt.Exited,
+				signalName(attempt.Signal), attempt.StartTime.AsTime().Local().Format(time.RFC3339), attempt.EndTime.AsTime().Local().Format(time.RFC3339),
+				formatUsage(attempt.Usage)))
+		}
+	}
 	return nil
 }
+
+// signalName returns the signal name, or none.
+func signalName(signal string) string {
+	if signal == "" {
+		return "none"
+	}
+	return signal
+}
+
+// formatUsage formats the resources used by a job.
+func formatUsage(usage *proto.ResourceUsage) string {
+	return fmt.Sprintf("User CPU: %v System CPU: %v Max RSS: %v Blocks in: %v Blocks out: %v", usage.GetUserTime().AsDuration().Round(time.Millisecond),
+		usage.GetSystemTime().AsDuration().Round(time.Millisecond), formatBytes(usage.GetMaxRss()), usage.GetInBlock(), usage.GetOutBlock())
+}
+
+// formatBytes formats a size with a K, M or G suffix, like parseBytes parses it.
+func formatBytes(size int64) string {
+	switch {
+	case size >= 1<<30:
+		return fmt.Sprintf("%.1fG", float64(size)/(1<<30))
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1fM", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.1fK", float64(size)/(1<<10))
+	default:
+		return fmt.Sprintf("%d", size)
+	}
+}
@@ -0,0 +1,145 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/renatoaguimaraes/job-scheduler/internal/worker/proto"
+	"google.golang.org/grpc"
+)
+
+// policies available by flag value
+var policies = map[string]proto.ConcurrencyPolicy{
+	"allow":   proto.ConcurrencyPolicy_CONCURRENCY_ALLOW,
+	"forbid":  proto.ConcurrencyPolicy_CONCURRENCY_FORBID,
+	"replace": proto.ConcurrencyPolicy_CONCURRENCY_REPLACE,
+}
+
+type ScheduleCommand struct {
+	client proto.WorkerServiceClient
+}
+
+func NewScheduleCommand(client proto.WorkerServiceClient) Runner {
+	return &ScheduleCommand{
+		client: client,
+	}
+}
+
+// Run runs the schedule subcommands create, delete and list.
+func (c *ScheduleCommand) Run(args []string) error {
+	if len(args) < 1 {
+		return errors.New("you must pass a subcommand: create, delete or list")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	switch args[0] {
+	case "create":
+		return c.create(ctx, args[1:])
+	case "delete":
+		return c.delete(ctx, args[1:])
+	case "list":
+		return c.list(ctx)
+	default:
+		return fmt.Errorf("unknown schedule subcommand: %s", args[0])
+	}
+}
+
+// create schedules a job given the cron expression, the program name and arguments.
+func (c *ScheduleCommand) create(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("schedule create", flag.ContinueOnError)
+	timeZone := flags.String("tz", "", "time zone of the cron expression, e.g. America/Sao_Paulo (default UTC)")
+	policy := flags.String("policy", "allow", "policy of overlapping runs, allow, forbid or replace")
+	jobFlags := newJobFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	args = flags.Args()
+	if len(args) < 1 {
+		return errors.New("you must pass a cron expression")
+	}
+	command, err := jobFlags.request(args[1:])
+	if err != nil {
+		return err
+	}
+	p, ok := policies[*policy]
+	if !ok {
+		return fmt.Errorf("invalid policy %q", *policy)
+	}
+	req := proto.CreateScheduleRequest{
+		Cron:     args[0],
+		TimeZone: *timeZone,
+		Command:  command,
+		Policy:   p,
+	}
+	res, err := c.client.CreateSchedule(ctx, &req, grpc.WaitForReady(true))
+	if err != nil {
+		return err
+	}
+	os.Stdout.WriteString(fmt.Sprintf("Schedule %v is created\n", res.ScheduleID))
+	return nil
+}
+
+// delete removes a schedule.
+func (c *ScheduleCommand) delete(ctx context.Context, args []string) error {
+	if len(args) < 1 {
+		return errors.New("you must pass an argument")
+	}
+	req := proto.DeleteScheduleRequest{
+		ScheduleID: args[0],
+	}
+	if _, err := c.client.DeleteSchedule(ctx, &req, grpc.WaitForReady(true)); err != nil {
+		return err
+	}
+	os.Stdout.WriteString(fmt.Sprintf("Schedule %v has been deleted\n", req.ScheduleID))
+	return nil
+}
+
+// list prints the schedules as a table.
+func (c *ScheduleCommand) list(ctx context.Context) error {
+	res, err := c.client.ListSchedules(ctx, &proto.ListSchedulesRequest{}, grpc.WaitForReady(true))
+	if err != nil {
+		return err
+	}
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "SCHEDULE ID\tCRON\tTIME ZONE\tPOLICY\tJOBS\tLAST JOB\tNEXT RUN\tCOMMAND")
+	for _, sc := range res.Schedules {
+		last, next := "", ""
+		if len(sc.JobIDs) > 0 {
+			last = sc.JobIDs[len(sc.JobIDs)-1]
+		}
+		if sc.NextRun != nil {
+			next = sc.NextRun.AsTime().Local().Format(time.RFC3339)
+		}
+		var command []string
+		if sc.Command != nil {
+			command = append([]string{sc.Command.Name}, sc.Command.Args...)
+		}
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%d\t%s\t%s\t%s\n",
+			sc.ScheduleID,
+			sc.Cron,
+			sc.TimeZone,
+			policyName(sc.Policy),
+			len(sc.JobIDs),
+			last,
+			next,
+			strings.Join(command, " "),
+		)
+	}
+	return table.Flush()
+}
+
+// policyName returns the flag value of a policy.
+func policyName(policy proto.ConcurrencyPolicy) string {
+	for name, value := range policies {
+		if value == policy {
+			return name
+		}
+	}
+	return ""
+}
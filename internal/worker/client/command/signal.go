@@ -0,0 +1,40 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/renatoaguimaraes/job-scheduler/internal/worker/proto"
+	"google.golang.org/grpc"
+)
+
+type SignalCommand struct {
+	client proto.WorkerServiceClient
+}
+
+func NewSignalCommand(client proto.WorkerServiceClient) Runner {
+	return &SignalCommand{
+		client: client,
+	}
+}
+
+func (c *SignalCommand) Run(args []string) error {
+	if len(args) < 2 {
+		return errors.New("you must pass the job id and the signal, e.g. SIGHUP")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	command := proto.SignalRequest{
+		JobID:  args[0],
+		Signal: args[1],
+	}
+	_, err := c.client.Signal(ctx, &command, grpc.WaitForReady(true))
+	if err != nil {
+		return err
+	}
+	os.Stdout.WriteString(fmt.Sprintf("Signal %v has been sent to the job %v\n", command.Signal, command.JobID))
+	return nil
+}
@@ -3,14 +3,28 @@ package command
 import (
 	"context"
 	"errors"
+	"flag"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/renatoaguimaraes/job-scheduler/internal/worker/proto"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
+// cpuPeriod in microseconds used to convert the number of CPUs to a quota
+const cpuPeriod = 100000
+
+// isolations available by flag value
+var isolations = map[string]proto.Isolation{
+	"":           proto.Isolation_ISOLATION_DEFAULT,
+	"none":       proto.Isolation_ISOLATION_NONE,
+	"namespaces": proto.Isolation_ISOLATION_NAMESPACES,
+}
+
 type StartCommand struct {
 	client proto.WorkerServiceClient
 }
@@ -22,23 +36,233 @@ func NewStartCommand(client proto.WorkerServiceClient) Runner {
 }
 
 func (c *StartCommand) Run(args []string) error {
+	flags := flag.NewFlagSet("start", flag.ContinueOnError)
+	jobFlags := newJobFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	command, err := jobFlags.request(flags.Args())
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	res, err := c.client.Start(ctx, command, grpc.WaitForReady(true))
+	if err != nil {
+		return err
+	}
+	os.Stdout.WriteString(fmt.Sprintf("Job %v is started\n", res.JobID))
+	return nil
+}
+
+// jobFlags flags of a job command.
+type jobFlags struct {
+	cpuWeight *uint64
+	cpus      *float64
+	memory    *string
+	readBPS   ioFlag
+	writeBPS  ioFlag
+	isolation *string
+	timeout   *time.Duration
+	deadline  *string
+	attempts  *int
+	backoff   *time.Duration
+	maxDelay  *time.Duration
+	jitter    *float64
+	exitCodes *string
+	priority  *int
+	env       envFlag
+	dir       *string
+	user      *string
+	interact  *bool
+	tty       *bool
+}
+
+// newJobFlags defines the job flags in the flag set.
+func newJobFlags(flags *flag.FlagSet) *jobFlags {
+	f := jobFlags{
+		cpuWeight: flags.Uint64("cpu-weight", 0, "relative share of CPU time, from 1 to 10000"),
+		cpus:      flags.Float64("cpus", 0, "maximum number of CPUs, e.g. 0.5"),
+		memory:    flags.String("memory", "", "maximum memory, e.g. 512M"),
+		isolation: flags.String("isolation", "", "job isolation, none or namespaces (default server configuration)"),
+		timeout:   flags.Duration("timeout", 0, "maximum run duration, e.g. 1h"),
+		deadline:  flags.String("deadline", "", "time to stop the job, in RFC 3339 format"),
+		attempts:  flags.Int("attempts", 0, "maximum number of attempts of a failed job, including the first one"),
+		backoff:   flags.Duration("backoff", time.Second, "delay before the first retry, doubled on each retry"),
+		maxDelay:  flags.Duration("max-backoff", 0, "maximum delay between attempts, e.g. 1m"),
+		jitter:    flags.Float64("jitter", 0, "fraction of the delay randomly subtracted from it, from 0 to 1"),
+		exitCodes: flags.String("retry-exit-codes", "", "comma separated retryable exit codes (default any failure)"),
+		priority:  flags.Int("priority", 0, "priority of the job when queued, higher first"),
+		dir:       flags.String("cwd", "", "working directory of the job (default server working directory)"),
+		interact:  flags.Bool("interactive", false, "keep the job stdin open, to attach to the job"),
+		tty:       flags.Bool("tty", false, "run the job with a pseudo-terminal"),
+		user:      flags.String("user", "", "user the job runs as, a name or uid, optionally followed by :group, e.g. 1000:1000"),
+	}
+	flags.Var(&f.env, "env", "environment variable, e.g. KEY=value (repeatable)")
+	flags.Var(&f.readBPS, "io-read-bps", "maximum read rate of a device, e.g. 8:0=10M (repeatable)")
+	flags.Var(&f.writeBPS, "io-write-bps", "maximum write rate of a device, e.g. 8:0=10M (repeatable)")
+	return &f
+}
+
+// request creates the start request given the parsed
+// flags and the program name followed by its arguments.
+func (f *jobFlags) request(args []string) (*proto.StartRequest, error) {
 	if len(args) < 1 {
-		return errors.New("you must pass a program name")
+		return nil, errors.New("you must pass a program name")
 	}
 	var cargs []string
 	if len(args) > 1 {
 		cargs = append(cargs, args[1:]...)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
-	defer cancel()
-	command := proto.StartRequest{
-		Name: args[0],
-		Args: cargs,
+	iso, ok := isolations[*f.isolation]
+	if !ok {
+		return nil, fmt.Errorf("invalid isolation %q", *f.isolation)
+	}
+	memoryMax, err := parseBytes(*f.memory)
+	if err != nil {
+		return nil, err
+	}
+	limits := &proto.ResourceLimits{
+		CpuWeight: *f.cpuWeight,
+		MemoryMax: memoryMax,
+		Io:        mergeIOLimits(f.readBPS, f.writeBPS),
+	}
+	if *f.cpus > 0 {
+		limits.CpuQuota = int64(*f.cpus * cpuPeriod)
+		limits.CpuPeriod = cpuPeriod
+	}
+	deadline, err := parseTimestamp(*f.deadline)
+	if err != nil {
+		return nil, err
+	}
+	command := &proto.StartRequest{
+		Name:        args[0],
+		Args:        cargs,
+		Limits:      limits,
+		Isolation:   iso,
+		Deadline:    deadline,
+		Priority:    int32(*f.priority),
+		Env:         f.env,
+		Dir:         *f.dir,
+		User:        *f.user,
+		Interactive: *f.interact,
+		Tty:         *f.tty,
+	}
+	if *f.timeout > 0 {
+		command.Timeout = durationpb.New(*f.timeout)
 	}
-	res, err := c.client.Start(ctx, &command, grpc.WaitForReady(true))
+	if *f.attempts > 1 {
+		command.Retry = &proto.RetryPolicy{
+			MaxAttempts: int32(*f.attempts),
+			Backoff:     durationpb.New(*f.backoff),
+			MaxBackoff:  durationpb.New(*f.maxDelay),
+			Jitter:      *f.jitter,
+		}
+		if command.Retry.ExitCodes, err = parseExitCodes(*f.exitCodes); err != nil {
+			return nil, err
+		}
+	}
+	return command, nil
+}
+
+// parseExitCodes parses a comma separated list of exit codes.
+func parseExitCodes(value string) ([]int32, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var codes []int32
+	for _, field := range strings.Split(value, ",") {
+		code, err := strconv.ParseInt(strings.TrimSpace(field), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exit code %q", field)
+		}
+		codes = append(codes, int32(code))
+	}
+	return codes, nil
+}
+
+// ioFlag repeatable flag of device rates in the format major:minor=rate.
+type ioFlag map[string]uint64
+
+func (f *ioFlag) String() string {
+	return fmt.Sprint(map[string]uint64(*f))
+}
+
+func (f *ioFlag) Set(value string) error {
+	parts := strings.SplitN(value, "=", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("invalid device rate %q, expected major:minor=rate", value)
+	}
+	rate, err := parseBytes(parts[1])
 	if err != nil {
 		return err
 	}
-	os.Stdout.WriteString(fmt.Sprintf("Job %v is started\n", res.JobID))
+	if *f == nil {
+		*f = make(ioFlag)
+	}
+	(*f)[parts[0]] = uint64(rate)
+	return nil
+}
+
+// envFlag repeatable flag of environment variables.
+type envFlag []string
+
+func (f *envFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *envFlag) Set(value string) error {
+	if !strings.Contains(value, "=") {
+		return fmt.Errorf("invalid environment variable %q, expected KEY=value", value)
+	}
+	*f = append(*f, value)
 	return nil
 }
+
+// mergeIOLimits merges the read and write rates by device.
+func mergeIOLimits(read, write ioFlag) []*proto.IOLimit {
+	limits := make(map[string]*proto.IOLimit)
+	var devices []string
+	limit := func(device string) *proto.IOLimit {
+		if _, ok := limits[device]; !ok {
+			limits[device] = &proto.IOLimit{Device: device}
+			devices = append(devices, device)
+		}
+		return limits[device]
+	}
+	for device, rate := range read {
+		limit(device).ReadBPS = rate
+	}
+	for device, rate := range write {
+		limit(device).WriteBPS = rate
+	}
+	var res []*proto.IOLimit
+	for _, device := range devices {
+		res = append(res, limits[device])
+	}
+	return res
+}
+
+// parseBytes parses a size with an optional K, M or G suffix.
+func parseBytes(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	unit := int64(1)
+	switch strings.ToUpper(value[len(value)-1:]) {
+	case "K":
+		unit = 1 << 10
+	case "M":
+		unit = 1 << 20
+	case "G":
+		unit = 1 << 30
+	}
+	if unit > 1 {
+		value = value[:len(value)-1]
+	}
+	size, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || size < 0 {
+		return 0, fmt.Errorf("invalid size %q", value)
+	}
+	return size * unit, nil
+}
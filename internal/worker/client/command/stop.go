@@ -3,12 +3,14 @@ package command
 import (
 	"context"
 	"errors"
+	"flag"
 	"fmt"
 	"os"
 	"time"
 
 	"github.com/renatoaguimaraes/job-scheduler/internal/worker/proto"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 type StopCommand struct {
@@ -22,6 +24,13 @@ func NewStopCommand(client proto.WorkerServiceClient) Runner {
 }
 
 func (c *StopCommand) Run(args []string) error {
+	flags := flag.NewFlagSet("stop", flag.ContinueOnError)
+	grace := flags.Duration("grace", 0, "grace period before killing the job, e.g. 5s (default server configuration)")
+	force := flags.Bool("force", false, "kill the job immediately")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	args = flags.Args()
 	if len(args) < 1 {
 		return errors.New("you must pass an argument")
 	}
@@ -29,6 +38,10 @@ func (c *StopCommand) Run(args []string) error {
 	defer cancel()
 	command := proto.StopRequest{
 		JobID: args[0],
+		Force: *force,
+	}
+	if *grace > 0 {
+		command.GracePeriod = durationpb.New(*grace)
 	}
 	_, err := c.client.Stop(ctx, &command, grpc.WaitForReady(true))
 	if err != nil {
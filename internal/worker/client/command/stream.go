@@ -3,13 +3,31 @@ package command
 import (
 	"context"
 	"errors"
+	"flag"
+	"fmt"
+	"io"
 	"os"
 	"os/signal"
+	"strings"
+	"time"
 
 	"github.com/renatoaguimaraes/job-scheduler/internal/worker/proto"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// outputStreams available by flag value
+var outputStreams = map[string]proto.OutputStream{
+	"":       proto.OutputStream_OUTPUT_STREAM_ALL,
+	"stdout": proto.OutputStream_OUTPUT_STREAM_STDOUT,
+	"stderr": proto.OutputStream_OUTPUT_STREAM_STDERR,
+}
+
+// resumeDelay to request the stream again when the connection is lost
+const resumeDelay = time.Second
+
 type StreamCommand struct {
 	client proto.WorkerServiceClient
 }
@@ -20,13 +38,40 @@ func NewStreamCommand(client proto.WorkerServiceClient) Runner {
 	}
 }
 
+// Run streams the job output, the job stdout and stderr are
+// written to the local stdout and stderr. The stream is resumed
+// from the last offset when the connection is lost, and ends with
+// the exit status once the job is finished.
 func (c *StreamCommand) Run(args []string) error {
-	if len(args) < 1 {
+	flags := flag.NewFlagSet("stream", flag.ContinueOnError)
+	source := flags.String("stream", "", "output stream, stdout or stderr (default both)")
+	timestamps := flags.Bool("timestamps", false, "prefix each line with the time it was written")
+	since := flags.String("since", "", "stream the output written since a time in RFC 3339 format, or a duration ago, e.g. 10m")
+	offset := flags.Int64("offset", 0, "byte offset of the job log to stream from")
+	tail := flags.Int("tail", 0, "stream just the last lines of the job log (default all)")
+	follow := flags.Bool("follow", true, "follow the job output, otherwise stream the output written so far")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() < 1 {
 		return errors.New("you must pass an argument")
 	}
+	outputStream, ok := outputStreams[*source]
+	if !ok {
+		return fmt.Errorf("invalid output stream %q", *source)
+	}
+	sinceTime, err := parseSince(*since)
+	if err != nil {
+		return err
+	}
 	ctx, cancel := context.WithCancel(context.Background())
 	command := proto.StreamRequest{
-		JobID: args[0],
+		JobID:    flags.Arg(0),
+		Stream:   outputStream,
+		Since:    sinceTime,
+		Offset:   *offset,
+		Tail:     int32(*tail),
+		NoFollow: !*follow,
 	}
 	res, err := c.client.Stream(ctx, &command, grpc.WaitForReady(true))
 	if err != nil {
@@ -34,22 +79,86 @@ func (c *StreamCommand) Run(args []string) error {
 		return err
 	}
 	// runs the streaming in backgroud
+	done := make(chan error, 1)
 	go func() {
-		for {
-			out, err := res.Recv()
-			if err != nil {
-				return
-			}
-			os.Stdout.WriteString(out.Output)
-		}
+		done <- c.receive(ctx, &command, res, *timestamps)
 	}()
-	// waits for os signal to terminate the streaming
+	// waits for os signal to terminate the streaming, or the end of the stream
 	sigchan := make(chan os.Signal, 1)
 	signal.Notify(sigchan, os.Interrupt)
 	defer func() {
 		cancel()
 		signal.Stop(sigchan)
 	}()
-	<-sigchan
-	return nil
+	select {
+	case <-sigchan:
+		return nil
+	case err := <-done:
+		return err
+	}
+}
+
+// receive writes the job output until the end of the stream. The stream is
+// requested again from the offset of the last output when the connection
+// is lost, or with the same request if nothing was received yet.
+func (c *StreamCommand) receive(ctx context.Context, command *proto.StreamRequest, res proto.WorkerService_StreamClient, timestamps bool) error {
+	// a record continues the line of the previous one without line break
+	continued := make(map[proto.OutputStream]bool)
+	// received reports whether any output was received
+	received := false
+	for {
+		out, err := res.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if status.Code(err) == codes.Unavailable {
+			// the last lines were streamed already, the stream resumes from the offset
+			if received {
+				command.Tail = 0
+			}
+			select {
+			case <-time.After(resumeDelay):
+			case <-ctx.Done():
+				return nil
+			}
+			if res, err = c.client.Stream(ctx, command, grpc.WaitForReady(true)); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		command.Offset = out.Offset
+		received = true
+		// the job is finished, the last message carries its exit status
+		if out.Exit != nil {
+			os.Stderr.WriteString(fmt.Sprintf("Exit code: %v Exited: %v Signal: %v Termination: %v Timed out: %v\n",
+				out.Exit.ExitCode, out.Exit.Exited, signalName(out.Exit.Signal), terminations[out.Exit.Termination], out.Exit.TimedOut))
+			return nil
+		}
+		output := out.Output
+		if timestamps && !continued[out.Stream] {
+			output = out.Time.AsTime().Local().Format(time.RFC3339Nano) + " " + output
+		}
+		continued[out.Stream] = !strings.HasSuffix(out.Output, "\n")
+		writeOutput(out.Stream, output)
+	}
+}
+
+// parseSince parses a time in RFC 3339 format, or a duration before now.
+func parseSince(value string) (*timestamppb.Timestamp, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		return timestamppb.New(time.Now().Add(-d)), nil
+	}
+	return parseTimestamp(value)
+}
+
+// writeOutput writes the job output to the matching local stream.
+func writeOutput(stream proto.OutputStream, output string) {
+	if stream == proto.OutputStream_OUTPUT_STREAM_STDERR {
+		os.Stderr.WriteString(output)
+		return
+	}
+	os.Stdout.WriteString(output)
 }
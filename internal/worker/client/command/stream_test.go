@@ -0,0 +1,79 @@
+package command
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/renatoaguimaraes/job-scheduler/internal/worker/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+// fakeStreamClient returns the responses of each requested stream, in order.
+type fakeStreamClient struct {
+	proto.WorkerServiceClient
+	streams  [][]*proto.StreamResponse
+	requests []*proto.StreamRequest
+}
+
+func (c *fakeStreamClient) Stream(ctx context.Context, in *proto.StreamRequest, opts ...grpc.CallOption) (proto.WorkerService_StreamClient, error) {
+	c.requests = append(c.requests, protobuf.Clone(in).(*proto.StreamRequest))
+	responses := c.streams[0]
+	c.streams = c.streams[1:]
+	return &fakeStream{responses: responses}, nil
+}
+
+// fakeStream sends the responses, a nil response drops the connection,
+// and the stream ends after the last one.
+type fakeStream struct {
+	grpc.ClientStream
+	responses []*proto.StreamResponse
+}
+
+func (s *fakeStream) Recv() (*proto.StreamResponse, error) {
+	if len(s.responses) == 0 {
+		return nil, io.EOF
+	}
+	res := s.responses[0]
+	s.responses = s.responses[1:]
+	if res == nil {
+		return nil, status.Error(codes.Unavailable, "connection lost")
+	}
+	return res, nil
+}
+
+func TestStreamResumeBeforeOutput(t *testing.T) {
+	client := &fakeStreamClient{streams: [][]*proto.StreamResponse{{nil}, {}}}
+	command := &proto.StreamRequest{JobID: "job", Tail: 10}
+	c := &StreamCommand{client: client}
+	res, err := client.Stream(context.Background(), command)
+	require.NoError(t, err)
+
+	require.NoError(t, c.receive(context.Background(), command, res, false))
+
+	// nothing was received, the last lines are requested again
+	require.Len(t, client.requests, 2)
+	assert.Equal(t, int32(10), client.requests[1].Tail)
+	assert.Zero(t, client.requests[1].Offset)
+}
+
+func TestStreamResumeAfterOutput(t *testing.T) {
+	output := &proto.StreamResponse{Output: "line 0\n", Offset: 42}
+	client := &fakeStreamClient{streams: [][]*proto.StreamResponse{{output, nil}, {}}}
+	command := &proto.StreamRequest{JobID: "job", Tail: 10}
+	c := &StreamCommand{client: client}
+	res, err := client.Stream(context.Background(), command)
+	require.NoError(t, err)
+
+	require.NoError(t, c.receive(context.Background(), command, res, false))
+
+	// the stream resumes from the offset of the last output
+	require.Len(t, client.requests, 2)
+	assert.Zero(t, client.requests[1].Tail)
+	assert.Equal(t, int64(42), client.requests[1].Offset)
+}
@@ -0,0 +1,67 @@
+package command
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+	"unsafe"
+)
+
+// winsize is the struct winsize used by the TIOCGWINSZ ioctl.
+type winsize struct {
+	rows   uint16
+	cols   uint16
+	xpixel uint16
+	ypixel uint16
+}
+
+// isTerminal checks if the file descriptor is a terminal.
+func isTerminal(fd int) bool {
+	var termios syscall.Termios
+	return ioctl(fd, syscall.TCGETS, unsafe.Pointer(&termios)) == nil
+}
+
+// makeRaw puts the terminal in raw mode, like cfmakeraw(3), so the input
+// is forwarded as typed, and returns the function to restore the terminal.
+func makeRaw(fd int) (func() error, error) {
+	var termios syscall.Termios
+	if err := ioctl(fd, syscall.TCGETS, unsafe.Pointer(&termios)); err != nil {
+		return nil, err
+	}
+	old := termios
+	termios.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP |
+		syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON
+	termios.Oflag &^= syscall.OPOST
+	termios.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	termios.Cflag &^= syscall.CSIZE | syscall.PARENB
+	termios.Cflag |= syscall.CS8
+	termios.Cc[syscall.VMIN] = 1
+	termios.Cc[syscall.VTIME] = 0
+	if err := ioctl(fd, syscall.TCSETS, unsafe.Pointer(&termios)); err != nil {
+		return nil, err
+	}
+	return func() error {
+		return ioctl(fd, syscall.TCSETS, unsafe.Pointer(&old))
+	}, nil
+}
+
+// windowSize gets the terminal window size.
+func windowSize(fd int) (rows uint32, cols uint32, err error) {
+	var ws winsize
+	if err := ioctl(fd, syscall.TIOCGWINSZ, unsafe.Pointer(&ws)); err != nil {
+		return 0, 0, err
+	}
+	return uint32(ws.rows), uint32(ws.cols), nil
+}
+
+// notifyResize relays the terminal window size changes to the channel.
+func notifyResize(c chan<- os.Signal) {
+	signal.Notify(c, syscall.SIGWINCH)
+}
+
+func ioctl(fd int, req uint, arg unsafe.Pointer) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), uintptr(req), uintptr(arg)); errno != 0 {
+		return errno
+	}
+	return nil
+}
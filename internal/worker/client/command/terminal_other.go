@@ -0,0 +1,27 @@
+//go:build !linux
+// +build !linux
+
+package command
+
+import (
+	"errors"
+	"os"
+)
+
+// isTerminal checks if the file descriptor is a terminal.
+func isTerminal(fd int) bool {
+	return false
+}
+
+// makeRaw isn't supported out of Linux.
+func makeRaw(fd int) (func() error, error) {
+	return nil, errors.New("raw terminal mode is only supported on Linux")
+}
+
+// windowSize isn't supported out of Linux.
+func windowSize(fd int) (rows uint32, cols uint32, err error) {
+	return 0, 0, errors.New("terminal window size is only supported on Linux")
+}
+
+// notifyResize doesn't relay the window size changes out of Linux.
+func notifyResize(c chan<- os.Signal) {}
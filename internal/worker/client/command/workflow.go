@@ -0,0 +1,231 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/renatoaguimaraes/job-scheduler/internal/worker/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"gopkg.in/yaml.v3"
+)
+
+// conditions available by definition value
+var conditions = map[string]proto.StepCondition{
+	"":        proto.StepCondition_STEP_CONDITION_SUCCESS,
+	"success": proto.StepCondition_STEP_CONDITION_SUCCESS,
+	"failure": proto.StepCondition_STEP_CONDITION_FAILURE,
+	"always":  proto.StepCondition_STEP_CONDITION_ALWAYS,
+}
+
+// workflowStates names by state
+var workflowStates = map[proto.WorkflowState]string{
+	proto.WorkflowState_WORKFLOW_STATE_UNSPECIFIED: "unknown",
+	proto.WorkflowState_WORKFLOW_RUNNING:           "running",
+	proto.WorkflowState_WORKFLOW_SUCCEEDED:         "succeeded",
+	proto.WorkflowState_WORKFLOW_FAILED:            "failed",
+	proto.WorkflowState_WORKFLOW_CANCELLED:         "cancelled",
+}
+
+// stepStates names by state
+var stepStates = map[proto.StepState]string{
+	proto.StepState_STEP_STATE_UNSPECIFIED: "unknown",
+	proto.StepState_STEP_PENDING:           "pending",
+	proto.StepState_STEP_RUNNING:           "running",
+	proto.StepState_STEP_SUCCEEDED:         "succeeded",
+	proto.StepState_STEP_FAILED:            "failed",
+	proto.StepState_STEP_SKIPPED:           "skipped",
+	proto.StepState_STEP_CANCELLED:         "cancelled",
+}
+
+// workflowDefinition is the workflow file format, YAML or JSON, e.g.
+//
+//	name: release
+//	steps:
+//	  - name: build
+//	    command: make
+//	    args: [build]
+//	  - name: notify
+//	    command: notify.sh
+//	    after:
+//	      - step: build
+//	        condition: failure
+type workflowDefinition struct {
+	Name  string           `yaml:"name"`
+	Steps []stepDefinition `yaml:"steps"`
+}
+
+// stepDefinition is a workflow step in the workflow file.
+type stepDefinition struct {
+	Name      string                 `yaml:"name"`
+	Command   string                 `yaml:"command"`
+	Args      []string               `yaml:"args"`
+	Isolation string                 `yaml:"isolation"`
+	Timeout   time.Duration          `yaml:"timeout"`
+	Attempts  int                    `yaml:"attempts"`
+	Backoff   time.Duration          `yaml:"backoff"`
+	After     []dependencyDefinition `yaml:"after"`
+}
+
+// dependencyDefinition is a step dependency in the workflow file, the
+// condition is success, failure or always, success if empty.
+type dependencyDefinition struct {
+	Step      string `yaml:"step"`
+	Condition string `yaml:"condition"`
+}
+
+type WorkflowCommand struct {
+	client proto.WorkerServiceClient
+}
+
+func NewWorkflowCommand(client proto.WorkerServiceClient) Runner {
+	return &WorkflowCommand{
+		client: client,
+	}
+}
+
+// Run runs the workflow subcommands create, query, stop and list.
+func (c *WorkflowCommand) Run(args []string) error {
+	if len(args) < 1 {
+		return errors.New("you must pass a subcommand: create, query, stop or list")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	switch args[0] {
+	case "create":
+		return c.create(ctx, args[1:])
+	case "query":
+		return c.query(ctx, args[1:])
+	case "stop":
+		return c.stop(ctx, args[1:])
+	case "list":
+		return c.list(ctx)
+	default:
+		return fmt.Errorf("unknown workflow subcommand: %s", args[0])
+	}
+}
+
+// create starts a workflow given the definition file, or - to read it from the stdin.
+func (c *WorkflowCommand) create(ctx context.Context, args []string) error {
+	if len(args) < 1 {
+		return errors.New("you must pass a workflow file")
+	}
+	var file io.Reader = os.Stdin
+	if args[0] != "-" {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		file = f
+	}
+	req, err := parseWorkflow(file)
+	if err != nil {
+		return err
+	}
+	res, err := c.client.CreateWorkflow(ctx, req, grpc.WaitForReady(true))
+	if err != nil {
+		return err
+	}
+	os.Stdout.WriteString(fmt.Sprintf("Workflow %v is created\n", res.WorkflowID))
+	return nil
+}
+
+// parseWorkflow reads a YAML or JSON workflow definition.
+func parseWorkflow(r io.Reader) (*proto.CreateWorkflowRequest, error) {
+	var def workflowDefinition
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&def); err != nil {
+		return nil, fmt.Errorf("invalid workflow definition: %v", err)
+	}
+	req := &proto.CreateWorkflowRequest{Name: def.Name}
+	for _, step := range def.Steps {
+		iso, ok := isolations[step.Isolation]
+		if !ok {
+			return nil, fmt.Errorf("invalid isolation %q of the step %q", step.Isolation, step.Name)
+		}
+		command := &proto.StartRequest{
+			Name:      step.Command,
+			Args:      step.Args,
+			Isolation: iso,
+		}
+		if step.Timeout > 0 {
+			command.Timeout = durationpb.New(step.Timeout)
+		}
+		if step.Attempts > 1 {
+			command.Retry = &proto.RetryPolicy{
+				MaxAttempts: int32(step.Attempts),
+				Backoff:     durationpb.New(step.Backoff),
+			}
+		}
+		st := &proto.WorkflowStep{Name: step.Name, Command: command}
+		for _, dep := range step.After {
+			condition, ok := conditions[dep.Condition]
+			if !ok {
+				return nil, fmt.Errorf("invalid condition %q of the step %q", dep.Condition, step.Name)
+			}
+			st.After = append(st.After, &proto.StepDependency{Step: dep.Step, Condition: condition})
+		}
+		req.Steps = append(req.Steps, st)
+	}
+	return req, nil
+}
+
+// query prints the workflow state and its steps as a table.
+func (c *WorkflowCommand) query(ctx context.Context, args []string) error {
+	if len(args) < 1 {
+		return errors.New("you must pass an argument")
+	}
+	res, err := c.client.QueryWorkflow(ctx, &proto.QueryWorkflowRequest{WorkflowID: args[0]}, grpc.WaitForReady(true))
+	if err != nil {
+		return err
+	}
+	wf := res.Workflow
+	os.Stdout.WriteString(fmt.Sprintf("Workflow: %v Name: %v State: %v\n", wf.WorkflowID, wf.Name, workflowStates[wf.State]))
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "STEP\tSTATE\tJOB ID\tEXIT CODE\tERROR")
+	for _, step := range wf.Steps {
+		fmt.Fprintf(table, "%s\t%s\t%s\t%d\t%s\n", step.Name, stepStates[step.State], step.JobID, step.ExitCode, step.Error)
+	}
+	return table.Flush()
+}
+
+// stop cancels a workflow.
+func (c *WorkflowCommand) stop(ctx context.Context, args []string) error {
+	if len(args) < 1 {
+		return errors.New("you must pass an argument")
+	}
+	req := proto.StopWorkflowRequest{
+		WorkflowID: args[0],
+	}
+	if _, err := c.client.StopWorkflow(ctx, &req, grpc.WaitForReady(true)); err != nil {
+		return err
+	}
+	os.Stdout.WriteString(fmt.Sprintf("Workflow %v has been stopped\n", req.WorkflowID))
+	return nil
+}
+
+// list prints the workflows as a table.
+func (c *WorkflowCommand) list(ctx context.Context) error {
+	res, err := c.client.ListWorkflows(ctx, &proto.ListWorkflowsRequest{}, grpc.WaitForReady(true))
+	if err != nil {
+		return err
+	}
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "WORKFLOW ID\tNAME\tSTATE\tSTEPS")
+	for _, wf := range res.Workflows {
+		var steps []string
+		for _, step := range wf.Steps {
+			steps = append(steps, fmt.Sprintf("%s:%s", step.Name, stepStates[step.State]))
+		}
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\n", wf.WorkflowID, wf.Name, workflowStates[wf.State], strings.Join(steps, " "))
+	}
+	return table.Flush()
+}
@@ -0,0 +1,219 @@
+package cgroup
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// cgroup2SuperMagic is the file system type of a cgroup v2 hierarchy.
+// See https://man7.org/linux/man-pages/man2/statfs.2.html
+const cgroup2SuperMagic = 0x63677270
+
+// Limits of resources available for a job.
+type Limits struct {
+	// CPUWeight relative share of CPU time, in the range [1, 10000]
+	CPUWeight uint64
+	// CPUQuota maximum CPU time in microseconds for each CPUPeriod
+	CPUQuota int64
+	// CPUPeriod in microseconds, the kernel default 100000 is used if zero
+	CPUPeriod uint64
+	// MemoryMax hard memory limit in bytes
+	MemoryMax int64
+	// IO bandwidth limits by block device
+	IO []IOLimit
+}
+
+// IOLimit bandwidth limits of a block device.
+type IOLimit struct {
+	// Device major:minor numbers, e.g. 8:0
+	Device string
+	// ReadBPS maximum read bytes per second
+	ReadBPS uint64
+	// WriteBPS maximum write bytes per second
+	WriteBPS uint64
+}
+
+// IsZero reports whether no limit is set.
+func (l Limits) IsZero() bool {
+	return l.CPUWeight == 0 && l.CPUQuota == 0 && l.MemoryMax == 0 && len(l.IO) == 0
+}
+
+// controllers returns the cgroup controllers required to apply the limits.
+func (l Limits) controllers() []string {
+	var ctrls []string
+	if l.CPUWeight > 0 || l.CPUQuota > 0 {
+		ctrls = append(ctrls, "cpu")
+	}
+	if l.MemoryMax > 0 {
+		ctrls = append(ctrls, "memory")
+	}
+	if len(l.IO) > 0 {
+		ctrls = append(ctrls, "io")
+	}
+	return ctrls
+}
+
+// setting is a value written to a cgroup interface file.
+type setting struct {
+	file  string
+	value string
+}
+
+// settings maps the limits to the cgroup interface files.
+func (l Limits) settings() []setting {
+	var settings []setting
+	if l.CPUWeight > 0 {
+		settings = append(settings, setting{"cpu.weight", strconv.FormatUint(l.CPUWeight, 10)})
+	}
+	if l.CPUQuota > 0 {
+		period := l.CPUPeriod
+		if period == 0 {
+			period = 100000
+		}
+		settings = append(settings, setting{"cpu.max", fmt.Sprintf("%d %d", l.CPUQuota, period)})
+	}
+	if l.MemoryMax > 0 {
+		settings = append(settings, setting{"memory.max", strconv.FormatInt(l.MemoryMax, 10)})
+	}
+	// the kernel accepts a single device for each write
+	for _, dev := range l.IO {
+		value := dev.Device
+		if dev.ReadBPS > 0 {
+			value += fmt.Sprintf(" rbps=%d", dev.ReadBPS)
+		}
+		if dev.WriteBPS > 0 {
+			value += fmt.Sprintf(" wbps=%d", dev.WriteBPS)
+		}
+		settings = append(settings, setting{"io.max", value})
+	}
+	return settings
+}
+
+// Cgroup is a control group v2 dedicated to a single job.
+type Cgroup struct {
+	path string
+}
+
+// New creates a control group under the given cgroup v2 folder and applies
+// the limits. The controllers required by the limits are enabled in the
+// folder, which is created if it doesn't exist.
+func New(folder, name string, limits Limits) (*Cgroup, error) {
+	if err := setup(folder, limits.controllers()); err != nil {
+		return nil, err
+	}
+	cg := &Cgroup{path: filepath.Join(folder, name)}
+	if err := os.Mkdir(cg.path, 0755); err != nil {
+		return nil, err
+	}
+	for _, s := range limits.settings() {
+		if err := cg.write(s.file, s.value); err != nil {
+			cg.Remove()
+			return nil, fmt.Errorf("fail to set %s: %v", s.file, err)
+		}
+	}
+	return cg, nil
+}
+
+// Open returns an existing control group, e.g. of a job
+// started before the worker restart.
+func Open(folder, name string) *Cgroup {
+	return &Cgroup{path: filepath.Join(folder, name)}
+}
+
+// setup creates the folder and enables the controllers for its children.
+func setup(folder string, controllers []string) error {
+	if err := os.MkdirAll(folder, 0755); err != nil {
+		return err
+	}
+	if !isCgroup2(folder) {
+		return fmt.Errorf("%s is not a cgroup v2 hierarchy", folder)
+	}
+	if len(controllers) == 0 {
+		return nil
+	}
+	var enable []string
+	for _, ctrl := range controllers {
+		enable = append(enable, "+"+ctrl)
+	}
+	// the parent must delegate the controllers to the folder, unless the
+	// folder is the hierarchy root
+	if parent := filepath.Dir(folder); isCgroup2(parent) {
+		if err := writeFile(parent, "cgroup.subtree_control", strings.Join(enable, " ")); err != nil {
+			return fmt.Errorf("fail to enable controllers %v: %v", controllers, err)
+		}
+	}
+	if err := writeFile(folder, "cgroup.subtree_control", strings.Join(enable, " ")); err != nil {
+		return fmt.Errorf("fail to enable controllers %v: %v", controllers, err)
+	}
+	return nil
+}
+
+// isCgroup2 checks if the path belongs to a cgroup v2 file system.
+func isCgroup2(path string) bool {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return false
+	}
+	return stat.Type == cgroup2SuperMagic
+}
+
+// Path returns the absolute path of the control group.
+func (c *Cgroup) Path() string {
+	return c.path
+}
+
+// AddProcess moves a process into the control group.
+func (c *Cgroup) AddProcess(pid int) error {
+	return c.write("cgroup.procs", strconv.Itoa(pid))
+}
+
+// Kill kills all processes of the control group,
+// supported since Linux 5.14.
+func (c *Cgroup) Kill() error {
+	return c.write("cgroup.kill", "1")
+}
+
+// Freeze suspends all processes of the control group,
+// supported since Linux 5.2.
+func (c *Cgroup) Freeze() error {
+	return c.write("cgroup.freeze", "1")
+}
+
+// Thaw resumes the processes of a frozen control group.
+func (c *Cgroup) Thaw() error {
+	return c.write("cgroup.freeze", "0")
+}
+
+// Remove deletes the control group. Processes left behind by the job are
+// killed, when supported by the kernel, so the control group can be removed.
+func (c *Cgroup) Remove() error {
+	var err error
+	for retry := 0; retry < 10; retry++ {
+		if err = os.Remove(c.path); err == nil || os.IsNotExist(err) {
+			return nil
+		}
+		if !errors.Is(err, syscall.EBUSY) {
+			return err
+		}
+		c.Kill()
+		time.Sleep(time.Millisecond * 10 * time.Duration(retry+1))
+	}
+	return err
+}
+
+// write writes a value to a control group interface file.
+func (c *Cgroup) write(file, value string) error {
+	return writeFile(c.path, file, value)
+}
+
+// writeFile writes a value to an interface file given a folder.
+func writeFile(folder, file, value string) error {
+	return ioutil.WriteFile(filepath.Join(folder, file), []byte(value), 0644)
+}
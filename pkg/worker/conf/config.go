@@ -1,6 +1,28 @@
 package conf
 
-import "os"
+import (
+	"os"
+	"time"
+)
+
+const (
+	// LogLimitTruncate removes the oldest rotated files of a job log
+	// when its maximum size is reached, or drops the new output
+	LogLimitTruncate = "truncate"
+	// LogLimitKill kills the job when its log maximum size is reached
+	LogLimitKill = "kill"
+)
+
+const (
+	// LogWatcherAuto watches the log files with inotify, or polls them
+	// if inotify isn't available for the log folder
+	LogWatcherAuto = "auto"
+	// LogWatcherInotify watches the log files with inotify
+	LogWatcherInotify = "inotify"
+	// LogWatcherPoll polls the size and modification time of the log
+	// files, for the file systems without inotify, e.g. NFS or FUSE
+	LogWatcherPoll = "poll"
+)
 
 // Config worker configuration.
 type Config struct {
@@ -8,6 +30,67 @@ type Config struct {
 	LogFolder string
 	// LogChunckSize size in bytes for each log chunck read from log file
 	LogChunckSize int
+	// LogWatcher how the streams following the log files watch them,
+	// LogWatcherAuto, LogWatcherInotify or LogWatcherPoll
+	LogWatcher string
+	// LogPollInterval interval to poll the log files
+	LogPollInterval time.Duration
+	// LogBufferSize number of records of a running job kept in memory for
+	// its streams, the streams follow the log files if zero
+	LogBufferSize int
+	// LogRotateSize size in bytes to rotate the log of a running job,
+	// no rotation if zero
+	LogRotateSize int64
+	// LogMaxSize maximum size in bytes of a job log, including the
+	// rotated files, no limit if zero
+	LogMaxSize int64
+	// LogLimitAction when a job log reaches the maximum size,
+	// LogLimitTruncate or LogLimitKill
+	LogLimitAction string
+	// LogRetention time to keep the logs of finished jobs, forever if zero
+	LogRetention time.Duration
+	// LogCompressAfter time to compress the logs of finished jobs,
+	// never compressed if zero
+	LogCompressAfter time.Duration
+	// LogFolderBudget maximum total size in bytes of the job logs, the
+	// logs of the oldest finished jobs are deleted above it, no limit if zero
+	LogFolderBudget int64
+	// LogJanitorInterval interval to purge the logs of finished jobs
+	LogJanitorInterval time.Duration
+	// LogArchiveEndpoint URL of the S3 compatible object storage where the
+	// logs of finished jobs are archived, no archive if empty
+	LogArchiveEndpoint string
+	// LogArchiveBucket bucket of the archived logs
+	LogArchiveBucket string
+	// LogArchivePrefix key prefix of the archived logs
+	LogArchivePrefix string
+	// LogArchiveRegion region of the object storage
+	LogArchiveRegion string
+	// LogArchiveAccessKey access key id of the object storage
+	LogArchiveAccessKey string
+	// LogArchiveSecretKey secret access key of the object storage
+	LogArchiveSecretKey string
+	// CgroupFolder cgroup v2 folder where the job control groups are created
+	CgroupFolder string
+	// NamespaceIsolation runs the jobs in new namespaces by default
+	NamespaceIsolation bool
+	// JobStoreFile journal file where the jobs are stored, the
+	// jobs are kept just in memory if empty
+	JobStoreFile string
+	// StopGracePeriod to wait for a stopped job to exit before killing it
+	StopGracePeriod time.Duration
+	// MaxConcurrentJobs maximum number of running jobs, the next jobs
+	// are queued by priority, no limit if zero
+	MaxConcurrentJobs int
+	// RunAs users allowed by identity, a client certificate common name
+	// or role:<role>, to run jobs as other users. The users are names or
+	// ids as requested, and * allows any user
+	RunAs map[string][]string
+	// EnvAllowlist worker environment variables passed to the jobs,
+	// the other variables aren't, so the worker secrets don't leak
+	EnvAllowlist []string
+	// InheritEnv passes the whole worker environment to the jobs
+	InheritEnv bool
 
 	ServerAddress string
 
@@ -22,7 +105,16 @@ type Config struct {
 
 func NewConfig() Config {
 	return Config{
-		LogFolder:     os.TempDir(),
-		LogChunckSize: 1024,
+		LogFolder:          os.TempDir(),
+		LogChunckSize:      1024,
+		LogBufferSize:      1024,
+		LogWatcher:         LogWatcherAuto,
+		LogPollInterval:    time.Millisecond * 250,
+		LogLimitAction:     LogLimitTruncate,
+		LogJanitorInterval: time.Minute,
+		LogArchiveRegion:   "us-east-1",
+		CgroupFolder:       "/sys/fs/cgroup/job-scheduler",
+		StopGracePeriod:    time.Second * 10,
+		EnvAllowlist:       []string{"PATH", "HOME", "LANG"},
 	}
 }
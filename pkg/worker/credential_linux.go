@@ -0,0 +1,74 @@
+package worker
+
+import (
+	"fmt"
+	"os/user"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// credential resolves the user a job runs as, given a user name or uid,
+// optionally followed by a group name or gid, e.g. nobody or 1000:1000.
+// The supplementary groups of the user are kept, unless the group is given.
+func credential(spec string) (*syscall.Credential, error) {
+	parts := strings.SplitN(spec, ":", 2)
+	u, err := lookupUser(parts[0])
+	if err != nil {
+		return nil, err
+	}
+	uid, err := strconv.ParseUint(u.Uid, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid uid %q", u.Uid)
+	}
+	gid, err := strconv.ParseUint(u.Gid, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gid %q", u.Gid)
+	}
+	cred := &syscall.Credential{Uid: uint32(uid), Gid: uint32(gid)}
+	if len(parts) == 2 {
+		g, err := lookupGroup(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		if gid, err = strconv.ParseUint(g.Gid, 10, 32); err != nil {
+			return nil, fmt.Errorf("invalid gid %q", g.Gid)
+		}
+		cred.Gid = uint32(gid)
+		cred.Groups = []uint32{}
+		return cred, nil
+	}
+	// the supplementary groups can't be listed in some systems,
+	// so the job runs just with the primary group
+	groups, err := u.GroupIds()
+	if err != nil {
+		cred.Groups = []uint32{}
+		return cred, nil
+	}
+	for _, group := range groups {
+		if gid, err := strconv.ParseUint(group, 10, 32); err == nil {
+			cred.Groups = append(cred.Groups, uint32(gid))
+		}
+	}
+	return cred, nil
+}
+
+// lookupUser looks up a user by name, or by uid if numeric.
+func lookupUser(name string) (*user.User, error) {
+	if _, err := strconv.Atoi(name); err == nil {
+		if u, err := user.LookupId(name); err == nil {
+			return u, nil
+		}
+		// a uid without an account runs with the same gid
+		return &user.User{Uid: name, Gid: name}, nil
+	}
+	return user.Lookup(name)
+}
+
+// lookupGroup looks up a group by name, or by gid if numeric.
+func lookupGroup(name string) (*user.Group, error) {
+	if _, err := strconv.Atoi(name); err == nil {
+		return &user.Group{Gid: name}, nil
+	}
+	return user.LookupGroup(name)
+}
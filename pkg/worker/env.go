@@ -0,0 +1,97 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/renatoaguimaraes/job-scheduler/pkg/worker/conf"
+)
+
+// baseEnv returns the environment of the jobs, before the job variables are
+// merged: the worker variables in the allowlist, or all of them if inherited.
+func baseEnv(config conf.Config) []string {
+	if config.InheritEnv {
+		return os.Environ()
+	}
+	var env []string
+	for _, key := range config.EnvAllowlist {
+		if value, ok := os.LookupEnv(key); ok {
+			env = append(env, key+"="+value)
+		}
+	}
+	return env
+}
+
+// mergeEnv merges environment variables in the form key=value, the
+// variables of the overrides replace the variables with the same key.
+func mergeEnv(env []string, overrides []string) []string {
+	merged := make([]string, 0, len(env)+len(overrides))
+	index := make(map[string]int)
+	for _, vars := range [][]string{env, overrides} {
+		for _, kv := range vars {
+			key := strings.SplitN(kv, "=", 2)[0]
+			if i, ok := index[key]; ok {
+				merged[i] = kv
+				continue
+			}
+			index[key] = len(merged)
+			merged = append(merged, kv)
+		}
+	}
+	return merged
+}
+
+// lookPath looks the program of a job up the way the job runs it: a name with
+// a slash is relative to the job working directory, and a bare name is searched
+// in the PATH of the job environment, or the worker PATH if the job has none,
+// whose relative entries are relative to the job working directory as well.
+// It returns the absolute program path.
+func lookPath(name, dir string, env []string) (string, error) {
+	if strings.Contains(name, "/") {
+		return executable(resolve(dir, name))
+	}
+	path, ok := os.LookupEnv("PATH")
+	for _, kv := range env {
+		if strings.HasPrefix(kv, "PATH=") {
+			path, ok = strings.TrimPrefix(kv, "PATH="), true
+		}
+	}
+	if ok {
+		for _, folder := range filepath.SplitList(path) {
+			if folder == "" {
+				folder = "."
+			}
+			if file, err := executable(filepath.Join(resolve(dir, folder), name)); err == nil {
+				return file, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("executable file %q not found in the job $PATH", name)
+}
+
+// resolve returns a path relative to the job working directory, unless it's absolute.
+func resolve(dir, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
+
+// executable returns the absolute path of an executable file.
+func executable(file string) (string, error) {
+	file, err := filepath.Abs(file)
+	if err != nil {
+		return "", err
+	}
+	info, err := os.Stat(file)
+	if err != nil {
+		return "", err
+	}
+	if info.IsDir() || info.Mode()&0111 == 0 {
+		return "", errors.New(file + " is not an executable file")
+	}
+	return file, nil
+}
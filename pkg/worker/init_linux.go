@@ -0,0 +1,334 @@
+package worker
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+	"unsafe"
+)
+
+// initCommand is the process name used to re-execute the current
+// program as the init process of a job.
+const initCommand = "job-scheduler-init"
+
+// initPipeFd is the file descriptor of the pipe used by the worker
+// to send the job specification to the init process.
+const initPipeFd = 3
+
+// initStatusFd is the file descriptor of the pipe used by the init process
+// of an isolated job to send the wait status of the job program to the worker.
+const initStatusFd = 4
+
+// initSpec is the job specification sent to the init process.
+type initSpec struct {
+	// Path absolute program path
+	Path string
+	// Args program arguments, starting with the program name
+	Args []string
+	// Isolated reports whether the process runs in new namespaces
+	Isolated bool
+	// Hostname of the new UTS namespace
+	Hostname string
+	// TTY reports whether the stdin is the controlling terminal
+	TTY bool
+	// Env environment of the program, in the form key=value
+	Env []string
+	// Dir working directory of the program, the worker
+	// working directory is kept if empty
+	Dir string
+	// Credential of the user the program runs as, the worker
+	// user is kept if nil. The init process switches the user
+	// itself, after the namespaces setup which requires privileges.
+	Credential *syscall.Credential
+}
+
+// Init runs the job init process and never returns when the current process
+// was started by the Worker, otherwise it does nothing. Programs using the
+// Worker must call Init at the very beginning of the main function.
+//
+// The Worker doesn't execute the job program directly: it re-executes the
+// current program as an init process which waits for the Worker to finish the
+// job setup, e.g. move it into the job control group, and then replaces itself
+// with the job program. This way, the job program never runs before the setup.
+// The init process of an isolated job stays as the PID 1 of the job namespaces
+// instead, see supervise.
+func Init() {
+	if len(os.Args) == 0 || os.Args[0] != initCommand {
+		return
+	}
+	if err := runInit(); err != nil {
+		fmt.Fprintf(os.Stderr, "fail to start the job: %v\n", err)
+		os.Exit(127)
+	}
+}
+
+// runInit waits for the job specification and executes the job program.
+func runInit() error {
+	pipe := os.NewFile(initPipeFd, "init-pipe")
+	var spec initSpec
+	// blocks until the Worker finishes the job setup
+	err := json.NewDecoder(pipe).Decode(&spec)
+	pipe.Close()
+	if err != nil {
+		return fmt.Errorf("fail to read the job specification: %v", err)
+	}
+	if spec.Isolated {
+		if err := setupNamespaces(spec); err != nil {
+			return err
+		}
+	}
+	if spec.Credential != nil {
+		if err := setCredential(spec.Credential); err != nil {
+			return err
+		}
+	}
+	if spec.Dir != "" {
+		if err := os.Chdir(spec.Dir); err != nil {
+			return fmt.Errorf("fail to change the working directory: %v", err)
+		}
+	}
+	if spec.Isolated {
+		return supervise(spec)
+	}
+	return syscall.Exec(spec.Path, spec.Args, spec.Env)
+}
+
+// forwardedSignals are the signals the init process of an isolated job forwards
+// to the job program. The kernel drops the signals sent to the PID 1 of a PID
+// namespace unless it handles them, so the job program can't be the PID 1.
+var forwardedSignals = []os.Signal{
+	syscall.SIGHUP, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGUSR1, syscall.SIGUSR2,
+	syscall.SIGALRM, syscall.SIGTERM, syscall.SIGWINCH, syscall.SIGCONT, syscall.SIGTSTP,
+}
+
+// supervise runs the job program as a child of the init process of an isolated
+// job, in its own process group, which receives the forwarded signals. A SIGTSTP
+// is forwarded as a SIGSTOP, so the worker can pause the job program without
+// stopping the init process. The orphaned processes of the namespace are reaped
+// until the job program exits, then its wait status is sent to the worker and
+// the init process exits, which kills the processes left in the namespace.
+func supervise(spec initSpec) error {
+	status := os.NewFile(initStatusFd, "status-pipe")
+	// the job program doesn't inherit the status pipe
+	syscall.CloseOnExec(initStatusFd)
+	sigs := make(chan os.Signal, len(forwardedSignals))
+	signal.Notify(sigs, forwardedSignals...)
+	attr := &syscall.ProcAttr{
+		Env:   spec.Env,
+		Files: []uintptr{0, 1, 2},
+		Sys:   &syscall.SysProcAttr{Setpgid: true},
+	}
+	if spec.TTY {
+		// the job program reads from the terminal as the foreground process group
+		attr.Sys.Foreground = true
+		attr.Sys.Ctty = 0
+	}
+	pid, err := syscall.ForkExec(spec.Path, spec.Args, attr)
+	if err != nil {
+		return err
+	}
+	go func() {
+		for sig := range sigs {
+			if sig == syscall.SIGTSTP {
+				sig = syscall.SIGSTOP
+			}
+			syscall.Kill(-pid, sig.(syscall.Signal))
+		}
+	}()
+	for {
+		var ws syscall.WaitStatus
+		wpid, err := syscall.Wait4(-1, &ws, 0, nil)
+		if err == syscall.EINTR {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("fail to wait for the job program: %v", err)
+		}
+		if wpid != pid {
+			continue
+		}
+		signal.Stop(sigs)
+		if err := json.NewEncoder(status).Encode(ws); err != nil {
+			fmt.Fprintf(os.Stderr, "fail to send the job status: %v\n", err)
+		}
+		status.Close()
+		// exits right away, there is nothing left to clean up
+		if ws.Signaled() {
+			syscall.Exit(128 + int(ws.Signal()))
+		}
+		syscall.Exit(ws.ExitStatus())
+	}
+}
+
+// setCredential switches the process user and groups, like the
+// SysProcAttr.Credential of a command.
+func setCredential(cred *syscall.Credential) error {
+	if !cred.NoSetGroups {
+		groups := make([]int, len(cred.Groups))
+		for i, gid := range cred.Groups {
+			groups[i] = int(gid)
+		}
+		if err := syscall.Setgroups(groups); err != nil {
+			return fmt.Errorf("fail to set the groups: %v", err)
+		}
+	}
+	if err := syscall.Setgid(int(cred.Gid)); err != nil {
+		return fmt.Errorf("fail to set the gid: %v", err)
+	}
+	if err := syscall.Setuid(int(cred.Uid)); err != nil {
+		return fmt.Errorf("fail to set the uid: %v", err)
+	}
+	return nil
+}
+
+// setupNamespaces prepares the new namespaces from the inside: remounts /proc
+// so it shows just the job processes, sets the hostname and brings the
+// loopback interface up.
+func setupNamespaces(spec initSpec) error {
+	// keeps the mount events from propagating to the host
+	if err := syscall.Mount("", "/", "", syscall.MS_REC|syscall.MS_PRIVATE, ""); err != nil {
+		return fmt.Errorf("fail to make the mounts private: %v", err)
+	}
+	if err := syscall.Mount("proc", "/proc", "proc", syscall.MS_NOSUID|syscall.MS_NOEXEC|syscall.MS_NODEV, ""); err != nil {
+		return fmt.Errorf("fail to mount /proc: %v", err)
+	}
+	if err := syscall.Sethostname([]byte(spec.Hostname)); err != nil {
+		return fmt.Errorf("fail to set the hostname: %v", err)
+	}
+	if err := loopbackUp(); err != nil {
+		return fmt.Errorf("fail to bring the loopback interface up: %v", err)
+	}
+	return nil
+}
+
+// ifreq is the struct ifreq used by the network device ioctls,
+// holding the interface flags.
+type ifreq struct {
+	name  [syscall.IFNAMSIZ]byte
+	flags uint16
+	_     [22]byte
+}
+
+// loopbackUp sets the loopback interface of the network namespace up.
+// See https://man7.org/linux/man-pages/man7/netdevice.7.html
+func loopbackUp() error {
+	fd, err := syscall.Socket(syscall.AF_INET, syscall.SOCK_DGRAM|syscall.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return err
+	}
+	defer syscall.Close(fd)
+	var req ifreq
+	copy(req.name[:], "lo")
+	if err := ioctl(fd, syscall.SIOCGIFFLAGS, unsafe.Pointer(&req)); err != nil {
+		return err
+	}
+	req.flags |= syscall.IFF_UP | syscall.IFF_RUNNING
+	return ioctl(fd, syscall.SIOCSIFFLAGS, unsafe.Pointer(&req))
+}
+
+// ioctl wraps the ioctl system call.
+func ioctl(fd int, req uint, arg unsafe.Pointer) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), uintptr(req), uintptr(arg)); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// initProcess is a job init process started by the Worker.
+type initProcess struct {
+	cmd  *exec.Cmd
+	pipe *os.File
+	// isolated reports whether the init process runs in new namespaces
+	isolated bool
+	// status read side of the status pipe, nil if the job isn't isolated
+	status *os.File
+}
+
+// namespaces cloned for isolated jobs.
+const namespaces = syscall.CLONE_NEWPID | syscall.CLONE_NEWNS | syscall.CLONE_NEWNET |
+	syscall.CLONE_NEWUTS | syscall.CLONE_NEWIPC
+
+// newInitProcess prepares the init process of a job, which leads a new
+// process group and runs in new namespaces when isolated.
+func newInitProcess(isolated bool) (*initProcess, error) {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	cmd := exec.Command("/proc/self/exe")
+	cmd.Args = []string{initCommand}
+	cmd.ExtraFiles = []*os.File{reader}
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	proc := &initProcess{cmd: cmd, pipe: writer, isolated: isolated}
+	if isolated {
+		cmd.SysProcAttr.Cloneflags = namespaces
+		status, statusWriter, err := os.Pipe()
+		if err != nil {
+			reader.Close()
+			writer.Close()
+			return nil, err
+		}
+		cmd.ExtraFiles = append(cmd.ExtraFiles, statusWriter)
+		proc.status = status
+	}
+	return proc, nil
+}
+
+// start starts the init process, which waits for the job specification.
+func (p *initProcess) start() error {
+	err := p.cmd.Start()
+	// the read side of the specification pipe, and the write side
+	// of the status pipe, belong to the init process now
+	for _, file := range p.cmd.ExtraFiles {
+		file.Close()
+	}
+	if err != nil {
+		p.pipe.Close()
+		p.closeStatus()
+	}
+	return err
+}
+
+// programStatus returns the wait status of the job program sent by the init
+// process of an isolated job, once the init process exited. It returns false
+// if the job isn't isolated, or if the init process exited without sending it,
+// e.g. killed or failed to start the job program.
+func (p *initProcess) programStatus() (syscall.WaitStatus, bool) {
+	if p.status == nil {
+		return 0, false
+	}
+	defer p.closeStatus()
+	var ws syscall.WaitStatus
+	if err := json.NewDecoder(p.status).Decode(&ws); err != nil {
+		return 0, false
+	}
+	return ws, true
+}
+
+// closeStatus closes the read side of the status pipe, if any.
+func (p *initProcess) closeStatus() {
+	if p.status != nil {
+		p.status.Close()
+		p.status = nil
+	}
+}
+
+// exec sends the job specification, so the init process
+// replaces itself with the job program.
+func (p *initProcess) exec(spec initSpec) error {
+	defer p.pipe.Close()
+	return json.NewEncoder(p.pipe).Encode(spec)
+}
+
+// abort kills the init process before the job program runs.
+func (p *initProcess) abort() {
+	p.pipe.Close()
+	p.closeStatus()
+	if err := p.cmd.Process.Kill(); err != nil {
+		return
+	}
+	p.cmd.Wait()
+}
@@ -0,0 +1,130 @@
+package worker
+
+import (
+	"bufio"
+	"encoding/json"
+	logger "log"
+	"os"
+	"sync"
+)
+
+// journalStore JobStore implementation backed by an append-only journal
+// file, where each line is a JSON encoded JobRecord. The latest line of
+// a Job wins.
+type journalStore struct {
+	path string
+	file *os.File
+	mtx  sync.Mutex
+	// records latest record of each Job, loaded when opened
+	records []JobRecord
+}
+
+// NewJournalStore opens, or creates, a journal file to store the Job records.
+// The journal is compacted when opened, keeping just the latest record of each Job.
+func NewJournalStore(path string) (JobStore, error) {
+	records, err := readJournal(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeJournal(path, records); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &journalStore{path: path, file: file, records: records}, nil
+}
+
+// Save appends the record to the journal and flushes it to the disk.
+func (s *journalStore) Save(record JobRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if _, err := s.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	return s.file.Sync()
+}
+
+// Load returns the records read when the journal was opened.
+func (s *journalStore) Load() ([]JobRecord, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.records, nil
+}
+
+// Close closes the journal file.
+func (s *journalStore) Close() error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.file.Close()
+}
+
+// readJournal reads the latest record of each Job, in the order they were created.
+// A truncated line, written during a crash, is ignored.
+func readJournal(path string) ([]JobRecord, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var ids []string
+	latest := make(map[string]JobRecord)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var record JobRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			logger.Printf("Ignoring invalid journal record, %v", err)
+			continue
+		}
+		if _, ok := latest[record.ID]; !ok {
+			ids = append(ids, record.ID)
+		}
+		latest[record.ID] = record
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	records := make([]JobRecord, 0, len(ids))
+	for _, id := range ids {
+		records = append(records, latest[id])
+	}
+	return records, nil
+}
+
+// writeJournal atomically replaces the journal with the given records.
+func writeJournal(path string, records []JobRecord) error {
+	tmp := path + ".tmp"
+	file, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	writer := bufio.NewWriter(file)
+	encoder := json.NewEncoder(writer)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			file.Close()
+			return err
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
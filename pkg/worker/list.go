@@ -0,0 +1,176 @@
+package worker
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultPageSize of the List operation
+	defaultPageSize = 100
+	// maxPageSize of the List operation
+	maxPageSize = 1000
+)
+
+// StateFilter filters the Jobs by state.
+type StateFilter int
+
+const (
+	// StateFilterAll matches all Jobs.
+	StateFilterAll StateFilter = iota
+	// StateFilterRunning matches the Jobs which aren't finished, pending,
+	// running or stopping.
+	StateFilterRunning
+	// StateFilterExited matches the succeeded Jobs.
+	StateFilterExited
+	// StateFilterFailed matches the Jobs which are finished and haven't
+	// succeeded: failed, killed, timed out or lost.
+	StateFilterFailed
+)
+
+// match checks if the Job state matches the filter.
+func (f StateFilter) match(job *Job) bool {
+	switch f {
+	case StateFilterRunning:
+		return job.IsRunning()
+	case StateFilterExited:
+		return job.Status.State == StateSucceeded
+	case StateFilterFailed:
+		return !job.IsRunning() && job.Status.State != StateSucceeded
+	default:
+		return true
+	}
+}
+
+// ListOptions of the List operation, the zero value lists the first
+// page of all Jobs.
+type ListOptions struct {
+	// State of the Jobs
+	State StateFilter
+	// Name of the command, all commands if empty
+	Name string
+	// StartedAfter lists the Jobs started at or after the given time, if not zero
+	StartedAfter time.Time
+	// StartedBefore lists the Jobs started before the given time, if not zero
+	StartedBefore time.Time
+	// PageSize maximum number of Jobs, the default page size is used if zero
+	PageSize int
+	// PageToken of the page, returned by the previous List call
+	PageToken string
+}
+
+// match checks if a Job matches the filters.
+func (o ListOptions) match(job *Job) bool {
+	if o.Name != "" && job.Command.Name != o.Name {
+		return false
+	}
+	if !o.StartedAfter.IsZero() && job.Status.StartTime.Before(o.StartedAfter) {
+		return false
+	}
+	if !o.StartedBefore.IsZero() && !job.Status.StartTime.Before(o.StartedBefore) {
+		return false
+	}
+	return o.State.match(job)
+}
+
+// JobSummary is a snapshot of a Job.
+type JobSummary struct {
+	// ID job identifier
+	ID string
+	// Command requested
+	Command Command
+	// Status of the process
+	Status Status
+	// CreateTime when the Job was requested
+	CreateTime time.Time
+}
+
+// List returns the Jobs matching the filters, ordered by creation time and id.
+// The page token encodes the position of the last Job of the previous page,
+// so the pages are stable while new Jobs are created and queued Jobs are started.
+func (w *worker) List(options ListOptions) ([]JobSummary, string, error) {
+	size := options.PageSize
+	if size <= 0 {
+		size = defaultPageSize
+	}
+	if size > maxPageSize {
+		size = maxPageSize
+	}
+	var after *pageCursor
+	if options.PageToken != "" {
+		cursor, err := decodePageToken(options.PageToken)
+		if err != nil {
+			return nil, "", err
+		}
+		after = &cursor
+	}
+	w.mtx.RLock()
+	var jobs []JobSummary
+	for _, job := range w.jobs {
+		if !options.match(job) {
+			continue
+		}
+		summary := JobSummary{ID: job.ID, Command: job.Command, Status: *job.Status, CreateTime: job.CreateTime}
+		if after != nil && !after.before(summary) {
+			continue
+		}
+		jobs = append(jobs, summary)
+	}
+	w.mtx.RUnlock()
+	sort.Slice(jobs, func(i, j int) bool {
+		return cursorOf(jobs[i]).before(jobs[j])
+	})
+	if len(jobs) <= size {
+		return jobs, "", nil
+	}
+	jobs = jobs[:size]
+	return jobs, encodePageToken(cursorOf(jobs[size-1])), nil
+}
+
+// pageCursor position of a Job in the List order.
+type pageCursor struct {
+	createTime time.Time
+	id         string
+}
+
+// cursorOf returns the position of a Job.
+func cursorOf(job JobSummary) pageCursor {
+	return pageCursor{createTime: job.CreateTime, id: job.ID}
+}
+
+// before checks if the cursor comes before the Job.
+func (c pageCursor) before(job JobSummary) bool {
+	if !c.createTime.Equal(job.CreateTime) {
+		return c.createTime.Before(job.CreateTime)
+	}
+	return c.id < job.ID
+}
+
+// encodePageToken encodes a cursor as an opaque token.
+func encodePageToken(cursor pageCursor) string {
+	raw := fmt.Sprintf("%d:%s", cursor.createTime.UnixNano(), cursor.id)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodePageToken decodes an opaque token.
+func decodePageToken(token string) (pageCursor, error) {
+	invalid := errors.New("invalid page token")
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return pageCursor{}, invalid
+	}
+	parts := strings.SplitN(string(raw), ":", 2)
+	if len(parts) != 2 {
+		return pageCursor{}, invalid
+	}
+	nanos, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return pageCursor{}, invalid
+	}
+	return pageCursor{createTime: time.Unix(0, nanos), id: parts[1]}, nil
+}
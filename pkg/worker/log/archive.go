@@ -0,0 +1,215 @@
+package log
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/renatoaguimaraes/job-scheduler/pkg/worker/conf"
+)
+
+// ArchiveStore is a LogStore which archives the logs of the finished jobs in
+// a S3 compatible object storage, an object by log with the rotated files and
+// the current file concatenated. The logs are written and followed in the log
+// folder, the local copy of a log is purged as configured once it's archived,
+// and its records are read from the archive from then on.
+type ArchiveStore struct {
+	*Logger
+	client *s3Client
+	prefix string
+	mtx    sync.Mutex
+	// archived logs known to be archived
+	archived map[string]bool
+}
+
+// NewArchiveStore returns an ArchiveStore of the local logs and the archive
+// set in the configuration, see conf.Config.
+func NewArchiveStore(logger *Logger, config conf.Config) (*ArchiveStore, error) {
+	client, err := newS3Client(config.LogArchiveEndpoint, config.LogArchiveBucket, config.LogArchiveRegion,
+		config.LogArchiveAccessKey, config.LogArchiveSecretKey)
+	if err != nil {
+		return nil, err
+	}
+	return &ArchiveStore{
+		Logger:   logger,
+		client:   client,
+		prefix:   config.LogArchivePrefix,
+		archived: make(map[string]bool),
+	}, nil
+}
+
+// key returns the object key of an archived log.
+func (s *ArchiveStore) key(name string) string {
+	return s.prefix + name + ".log"
+}
+
+// Open opens the local log, or the archived log if the local copy is gone.
+func (s *ArchiveStore) Open(name string, offset int64) (io.ReadCloser, error) {
+	reader, err := s.Logger.Open(name, offset)
+	if !os.IsNotExist(err) {
+		return reader, err
+	}
+	reader, aerr := s.client.get(s.key(name), offset)
+	if isNotFound(aerr) {
+		return nil, err
+	}
+	if serr, ok := aerr.(*s3Error); ok && serr.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+		// nothing left from the offset
+		return ioutil.NopCloser(strings.NewReader("")), nil
+	}
+	return reader, aerr
+}
+
+// Tailf follows the local log, or streams the archived log if the local
+// copy is gone.
+func (s *ArchiveStore) Tailf(ctx context.Context, name string, options StreamOptions) (chan Output, error) {
+	logchan, err := s.Logger.Tailf(ctx, name, options)
+	if !os.IsNotExist(err) {
+		return logchan, err
+	}
+	reader, err := s.Open(name, options.Offset)
+	if err != nil {
+		return nil, err
+	}
+	logchan = make(chan Output)
+	go func() {
+		defer close(logchan)
+		defer reader.Close()
+		records := recordReader{file: reader, chunkSize: s.config.LogChunckSize, offset: options.Offset}
+		if options.Tail > 0 {
+			err = s.streamLast(ctx, &records, options, logchan)
+		} else {
+			err = s.streamFile(ctx, &records, options, logchan)
+		}
+		if err != nil && err != io.EOF && err != errFinished {
+			log.Printf("fail to read the archived log: %v", err)
+		}
+	}()
+	return logchan, nil
+}
+
+// streamLast streams the last records of an archived log, which is read
+// from the begin, and its exit status.
+func (s *ArchiveStore) streamLast(ctx context.Context, reader *recordReader, options StreamOptions, logchan chan Output) error {
+	last := make([]Output, options.Tail)
+	count := 0
+	var exit *Output
+	for {
+		record, err := reader.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if record.Exit != nil {
+			exit = &record
+			continue
+		}
+		last[count%options.Tail] = record
+		count++
+	}
+	from := 0
+	if count > options.Tail {
+		from = count - options.Tail
+	}
+	records := make([]Output, 0, options.Tail+1)
+	for i := from; i < count; i++ {
+		if record := last[i%options.Tail]; options.accept(record) {
+			records = append(records, record)
+		}
+	}
+	if exit != nil {
+		records = append(records, *exit)
+	}
+	for _, record := range records {
+		select {
+		case logchan <- record:
+		case <-ctx.Done():
+			return errors.New("log file stream cancelled")
+		}
+	}
+	return nil
+}
+
+// Remove removes the local and the archived log.
+func (s *ArchiveStore) Remove(name string) error {
+	err := s.Logger.Remove(name)
+	s.mtx.Lock()
+	delete(s.archived, name)
+	s.mtx.Unlock()
+	if aerr := s.client.delete(s.key(name)); aerr != nil {
+		return aerr
+	}
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// Purge archives the logs of the finished jobs, and then purges the local
+// logs, see Logger.Purge. The local copy of a log isn't purged until it's
+// archived.
+func (s *ArchiveStore) Purge(state JobState) error {
+	logs, err := s.jobLogs(state)
+	if err != nil {
+		return err
+	}
+	for _, jl := range logs {
+		if !jl.finished || s.isArchived(jl.name) {
+			continue
+		}
+		// the logs archived before a restart aren't uploaded again
+		archived, err := s.client.exists(s.key(jl.name))
+		if err == nil && !archived {
+			err = s.archive(jl.name)
+		}
+		if err != nil {
+			log.Printf("fail to archive the log %s: %v", jl.name, err)
+			continue
+		}
+		s.mtx.Lock()
+		s.archived[jl.name] = true
+		s.mtx.Unlock()
+	}
+	return s.Logger.Purge(func(name string) (bool, bool) {
+		known, finished := state(name)
+		return known, finished && s.isArchived(name)
+	})
+}
+
+// isArchived checks if the log is known to be archived.
+func (s *ArchiveStore) isArchived(name string) bool {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.archived[name]
+}
+
+// archive uploads a local log, the log is read twice, to sign its content
+// and to upload it.
+func (s *ArchiveStore) archive(name string) error {
+	reader, err := s.Logger.Open(name, 0)
+	if err != nil {
+		return err
+	}
+	hash := sha256.New()
+	size, err := io.Copy(hash, reader)
+	reader.Close()
+	if err != nil {
+		return err
+	}
+	reader, err = s.Logger.Open(name, 0)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	return s.client.put(s.key(name), reader, size, hex.EncodeToString(hash.Sum(nil)))
+}
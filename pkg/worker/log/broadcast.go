@@ -0,0 +1,210 @@
+package log
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"sync"
+)
+
+// errLagged is returned when a stream falls behind the buffered records
+var errLagged = errors.New("log stream lagged behind the buffer")
+
+// bufferedRecord is a record kept in memory with the log offset where it starts.
+type bufferedRecord struct {
+	record Output
+	start  int64
+}
+
+// broadcaster keeps the last records of a running log in a ring buffer, which
+// the streams of the log read from, so a single Writer fans out to all of them
+// without reading the log file nor watching it. The Writer never waits for
+// the streams, a stream which falls behind the buffer lags and reads the
+// records it missed from the log file.
+type broadcaster struct {
+	mtx     sync.Mutex
+	records []bufferedRecord
+	// next position of the next record published, the count of records
+	next uint64
+	// closed the log is closed, no more records are published
+	closed bool
+	// notify is closed when a record is published or the log is closed,
+	// if a stream waits for it
+	notify  chan struct{}
+	waiting bool
+}
+
+// newBroadcaster returns a broadcaster which keeps the last size records.
+func newBroadcaster(size int) *broadcaster {
+	return &broadcaster{
+		records: make([]bufferedRecord, size),
+		notify:  make(chan struct{}),
+	}
+}
+
+// publish buffers a record, overwriting the oldest one, and wakes the
+// waiting streams up.
+func (b *broadcaster) publish(record Output, start int64) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.records[b.next%uint64(len(b.records))] = bufferedRecord{record: record, start: start}
+	b.next++
+	b.wake()
+}
+
+// close wakes the waiting streams up, the records left are still read.
+func (b *broadcaster) close() {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.closed = true
+	b.wake()
+}
+
+// wake notifies the waiting streams. It must be called holding the lock.
+func (b *broadcaster) wake() {
+	if b.waiting {
+		close(b.notify)
+		b.notify = make(chan struct{})
+		b.waiting = false
+	}
+}
+
+// oldest returns the position of the oldest buffered record. It must be
+// called holding the lock.
+func (b *broadcaster) oldest() uint64 {
+	if size := uint64(len(b.records)); b.next > size {
+		return b.next - size
+	}
+	return 0
+}
+
+// at returns the buffered record of a position. It must be called holding
+// the lock.
+func (b *broadcaster) at(pos uint64) bufferedRecord {
+	return b.records[pos%uint64(len(b.records))]
+}
+
+// find returns the position of the first record streamed given the offset
+// and the last records of the options, or false if they aren't buffered.
+func (b *broadcaster) find(options StreamOptions) (uint64, bool) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	oldest := b.oldest()
+	pos := oldest
+	if options.Tail > 0 {
+		end := b.next
+		// the exit status isn't counted
+		if end > oldest && b.at(end-1).record.Exit != nil {
+			end--
+		}
+		if end >= oldest+uint64(options.Tail) {
+			pos = end - uint64(options.Tail)
+		} else if oldest > 0 {
+			return 0, false
+		}
+	}
+	if options.Offset > 0 {
+		if b.next == oldest || options.Offset < b.at(oldest).start {
+			return 0, false
+		}
+		// the first record ending after the offset
+		i := sort.Search(int(b.next-oldest), func(i int) bool {
+			return b.at(oldest+uint64(i)).record.Offset > options.Offset
+		})
+		if from := oldest + uint64(i); from > pos {
+			pos = from
+		}
+	} else if options.Tail == 0 && oldest > 0 {
+		// the log start was overwritten
+		return 0, false
+	}
+	return pos, true
+}
+
+// position returns the position of the first buffered record from a
+// sequence number, or false if the record isn't buffered anymore.
+func (b *broadcaster) position(seq uint64) (uint64, bool) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	oldest := b.oldest()
+	if b.next > oldest && b.at(oldest).record.Seq > seq {
+		return 0, false
+	}
+	i := sort.Search(int(b.next-oldest), func(i int) bool {
+		return b.at(oldest+uint64(i)).record.Seq >= seq
+	})
+	return oldest + uint64(i), true
+}
+
+// firstSeq returns the sequence number of the oldest buffered record, or
+// the maximum if none is buffered.
+func (b *broadcaster) firstSeq() uint64 {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	if b.next == b.oldest() {
+		return math.MaxUint64
+	}
+	return b.at(b.oldest()).record.Seq
+}
+
+// end returns the position of the next record published.
+func (b *broadcaster) end() uint64 {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return b.next
+}
+
+// read copies the records from a position to buf. It returns the number of
+// records copied and, if none, a chan closed when there is something new, or
+// nil if the log is closed. It returns errLagged if the record of the
+// position was overwritten.
+func (b *broadcaster) read(pos uint64, buf []Output) (int, <-chan struct{}, error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	if pos < b.oldest() {
+		return 0, nil, errLagged
+	}
+	n := 0
+	for ; n < len(buf) && pos+uint64(n) < b.next; n++ {
+		buf[n] = b.at(pos + uint64(n)).record
+	}
+	if n > 0 || b.closed {
+		return n, nil, nil
+	}
+	b.waiting = true
+	return 0, b.notify, nil
+}
+
+// stream sends the buffered records from a position, and the records
+// published from then on, until the log is closed. It returns the offset
+// and the sequence number of the last record read, when it lags behind.
+func (b *broadcaster) stream(ctx context.Context, pos uint64, options StreamOptions, logchan chan Output) (int64, uint64, error) {
+	var offset int64
+	var seq uint64
+	buf := make([]Output, 64)
+	for {
+		n, notify, err := b.read(pos, buf)
+		if err != nil {
+			return offset, seq, err
+		}
+		if n == 0 {
+			if notify == nil {
+				return offset, seq, nil
+			}
+			select {
+			case <-notify:
+				continue
+			case <-ctx.Done():
+				return offset, seq, errors.New("log stream cancelled")
+			}
+		}
+		for _, record := range buf[:n] {
+			offset, seq = record.Offset, record.Seq
+			if err := sendRecord(ctx, record, options, logchan); err != nil {
+				return offset, seq, err
+			}
+		}
+		pos += uint64(n)
+	}
+}
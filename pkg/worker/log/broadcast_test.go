@@ -0,0 +1,178 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/renatoaguimaraes/job-scheduler/pkg/worker/conf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBroadcaster(t *testing.T) {
+	b := newBroadcaster(4)
+	for i := 1; i <= 6; i++ {
+		b.publish(Output{Seq: uint64(i), Offset: int64(i * 10)}, int64((i-1)*10))
+	}
+
+	buf := make([]Output, 8)
+	n, notify, err := b.read(2, buf)
+	require.NoError(t, err)
+	assert.Nil(t, notify)
+	assert.Equal(t, []uint64{3, 4, 5, 6}, seqs(buf[:n]))
+	// the overwritten records lag
+	_, _, err = b.read(1, buf)
+	assert.Equal(t, errLagged, err)
+	// the streams wait for the next records
+	n, notify, err = b.read(6, buf)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	b.publish(Output{Seq: 7, Offset: 70}, 60)
+	select {
+	case <-notify:
+	default:
+		t.Fatal("stream not notified")
+	}
+
+	pos, ok := b.find(StreamOptions{Tail: 2})
+	assert.True(t, ok)
+	assert.Equal(t, uint64(5), pos)
+	pos, ok = b.find(StreamOptions{Offset: 40})
+	assert.True(t, ok)
+	assert.Equal(t, uint64(4), pos)
+	_, ok = b.find(StreamOptions{Offset: 10})
+	assert.False(t, ok)
+	_, ok = b.find(StreamOptions{Tail: 5})
+	assert.False(t, ok)
+	_, ok = b.find(StreamOptions{})
+	assert.False(t, ok)
+
+	b.close()
+	n, notify, err = b.read(7, buf)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	assert.Nil(t, notify)
+}
+
+func TestTailfLagged(t *testing.T) {
+	config := conf.NewConfig()
+	config.LogRotateSize = 200
+	config.LogBufferSize = 4
+	l := newTestLogger(t, config)
+	w, err := l.Create("job", nil)
+	require.NoError(t, err)
+	writeLines(t, w, 0, 10)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	logchan, err := l.Tailf(ctx, "job", StreamOptions{Tail: 2})
+	require.NoError(t, err)
+	records := []Output{<-logchan}
+	// the writer doesn't wait for the stream, which falls behind the
+	// buffer and reads the records it missed from the log files
+	writeLines(t, w, 10, 50)
+	require.NoError(t, w.Finish(Exit{Exited: true}))
+	for record := range logchan {
+		records = append(records, record)
+	}
+	require.NoError(t, ctx.Err())
+	require.Len(t, records, 43)
+	for i, record := range records {
+		assert.Equal(t, uint64(i+9), record.Seq)
+	}
+	assert.NotNil(t, records[42].Exit)
+	// the offsets are the ones of the log files
+	rest := streamRecords(t, l, StreamOptions{Offset: records[20].Offset})
+	assert.Equal(t, seqs(records[21:]), seqs(rest))
+}
+
+func TestTailfWatcher(t *testing.T) {
+	config := conf.NewConfig()
+	config.LogRotateSize = 200
+	config.LogBufferSize = 0
+	l := newTestLogger(t, config)
+	w, err := l.Create("job", nil)
+	require.NoError(t, err)
+	writeLines(t, w, 0, 5)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	// without buffer, the streams follow the log files
+	logchan, err := l.Tailf(ctx, "job", StreamOptions{})
+	require.NoError(t, err)
+	go func() {
+		time.Sleep(time.Millisecond * 200)
+		writeLines(t, w, 5, 10)
+		w.Finish(Exit{Exited: true})
+	}()
+	var records []Output
+	for record := range logchan {
+		records = append(records, record)
+	}
+	require.NoError(t, ctx.Err())
+	assert.Equal(t, []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}, seqs(records))
+}
+
+// benchmarkStreams measures the records streamed by second to concurrent
+// streams following a running log.
+func benchmarkStreams(b *testing.B, streams, bufferSize int) {
+	config := conf.NewConfig()
+	config.LogFolder = b.TempDir()
+	config.LogBufferSize = bufferSize
+	l := NewLogger(config)
+	w, err := l.Create("job", nil)
+	require.NoError(b, err)
+	line := []byte("the quick brown fox jumps over the lazy dog\n")
+	_, err = w.Stream(Stdout).Write(line)
+	require.NoError(b, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var wg sync.WaitGroup
+	for i := 0; i < streams; i++ {
+		logchan, err := l.Tailf(ctx, "job", StreamOptions{})
+		require.NoError(b, err)
+		// the stream follows the log once the first record is read
+		<-logchan
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range logchan {
+			}
+		}()
+	}
+	b.ResetTimer()
+	start := time.Now()
+	for i := 0; i < b.N; i++ {
+		if _, err := w.Stream(Stdout).Write(line); err != nil {
+			b.Fatal(err)
+		}
+	}
+	w.Finish(Exit{Exited: true})
+	wg.Wait()
+	b.StopTimer()
+	b.ReportMetric(float64(streams*b.N)/time.Since(start).Seconds(), "records/s")
+}
+
+// BenchmarkStreams compares the streams reading a buffer which holds the
+// whole output, the streams falling behind the default buffer, since the
+// writer doesn't wait for them, and the streams following the log files.
+func BenchmarkStreams(b *testing.B) {
+	for _, streams := range []int{1, 10, 100, 500} {
+		b.Run(fmt.Sprintf("buffer-%d", streams), func(b *testing.B) {
+			benchmarkStreams(b, streams, b.N+1)
+		})
+		b.Run(fmt.Sprintf("lagged-%d", streams), func(b *testing.B) {
+			benchmarkStreams(b, streams, conf.NewConfig().LogBufferSize)
+		})
+	}
+	// a watcher by stream, the inotify instances are limited to 128 by
+	// default, so hundreds of streams can't follow the log files
+	for _, streams := range []int{1, 10, 50} {
+		b.Run(fmt.Sprintf("files-%d", streams), func(b *testing.B) {
+			benchmarkStreams(b, streams, 0)
+		})
+	}
+}
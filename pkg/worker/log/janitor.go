@@ -0,0 +1,133 @@
+package log
+
+import (
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// JobState tells if a log belongs to a known job, and if the job is finished.
+// The janitor doesn't touch the other files in the log folder.
+type JobState func(name string) (known bool, finished bool)
+
+// jobLog files of a job log, the current, rotated and compressed files.
+type jobLog struct {
+	name     string
+	finished bool
+	files    []string
+	size     int64
+	modTime  time.Time
+}
+
+// Purge compresses and deletes the logs of the finished jobs. The logs are
+// compressed after LogCompressAfter and deleted after LogRetention, counted
+// from their last change, and the oldest ones are deleted while the job logs
+// are over LogFolderBudget, see conf.Config.
+func (l *Logger) Purge(state JobState) error {
+	logs, err := l.jobLogs(state)
+	if err != nil {
+		return err
+	}
+	var total int64
+	var finished []*jobLog
+	for _, jl := range logs {
+		if jl.finished {
+			age := time.Since(jl.modTime)
+			if l.config.LogRetention > 0 && age > l.config.LogRetention {
+				l.removeLog(jl)
+				continue
+			}
+			if l.config.LogCompressAfter > 0 && age > l.config.LogCompressAfter {
+				l.compressLog(jl)
+			}
+			finished = append(finished, jl)
+		}
+		total += jl.size
+	}
+	if l.config.LogFolderBudget <= 0 || total <= l.config.LogFolderBudget {
+		return nil
+	}
+	// the logs of the running jobs are kept
+	sort.Slice(finished, func(i, j int) bool {
+		return finished[i].modTime.Before(finished[j].modTime)
+	})
+	for _, jl := range finished {
+		if total <= l.config.LogFolderBudget {
+			break
+		}
+		l.removeLog(jl)
+		total -= jl.size
+	}
+	return nil
+}
+
+// jobLogs lists the logs of the known jobs in the log folder.
+func (l *Logger) jobLogs(state JobState) ([]*jobLog, error) {
+	entries, err := ioutil.ReadDir(l.config.LogFolder)
+	if err != nil {
+		return nil, err
+	}
+	logs := make(map[string]*jobLog)
+	var names []string
+	for _, entry := range entries {
+		i := strings.Index(entry.Name(), ".log")
+		if i <= 0 || entry.IsDir() {
+			continue
+		}
+		name := entry.Name()[:i]
+		jl, ok := logs[name]
+		if !ok {
+			known, finished := state(name)
+			if !known {
+				continue
+			}
+			jl = &jobLog{name: name, finished: finished}
+			logs[name] = jl
+			names = append(names, name)
+		}
+		jl.files = append(jl.files, filepath.Join(l.config.LogFolder, entry.Name()))
+		jl.size += entry.Size()
+		if entry.ModTime().After(jl.modTime) {
+			jl.modTime = entry.ModTime()
+		}
+	}
+	result := make([]*jobLog, 0, len(names))
+	for _, name := range names {
+		result = append(result, logs[name])
+	}
+	return result, nil
+}
+
+// removeLog deletes the files of a job log.
+func (l *Logger) removeLog(jl *jobLog) {
+	for _, file := range jl.files {
+		if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+			log.Printf("fail to remove the log file: %v", err)
+		}
+	}
+}
+
+// compressLog compresses the files of a job log, which aren't compressed yet.
+func (l *Logger) compressLog(jl *jobLog) {
+	var size int64
+	var files []string
+	for _, file := range jl.files {
+		if !strings.HasSuffix(file, gzipExt) {
+			if err := compress(file); err != nil {
+				log.Printf("fail to compress the log file: %v", err)
+			} else {
+				file += gzipExt
+			}
+		}
+		if stat, err := os.Stat(file); err == nil {
+			size += stat.Size()
+			files = append(files, file)
+		}
+	}
+	jl.files = files
+	jl.size = size
+}
@@ -0,0 +1,103 @@
+package log
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/renatoaguimaraes/job-scheduler/pkg/worker/conf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createLog writes a finished job log, changed some time ago.
+func createLog(t *testing.T, l *Logger, name string, lines int, age time.Duration) {
+	w, err := l.Create(name, nil)
+	require.NoError(t, err)
+	writeLines(t, w, 0, lines)
+	require.NoError(t, w.Close())
+	files, err := filepath.Glob(l.Path(name) + "*")
+	require.NoError(t, err)
+	modTime := time.Now().Add(-age)
+	for _, file := range files {
+		require.NoError(t, os.Chtimes(file, modTime, modTime))
+	}
+}
+
+func finishedJobs(names ...string) JobState {
+	return func(name string) (bool, bool) {
+		for _, n := range names {
+			if n == name {
+				return true, true
+			}
+		}
+		return false, false
+	}
+}
+
+func TestPurgeRetention(t *testing.T) {
+	config := conf.NewConfig()
+	config.LogRetention = time.Hour
+	l := newTestLogger(t, config)
+	createLog(t, l, "old", 5, time.Hour*2)
+	createLog(t, l, "new", 5, time.Minute)
+	createLog(t, l, "unknown", 5, time.Hour*2)
+
+	require.NoError(t, l.Purge(finishedJobs("old", "new")))
+
+	assert.NoFileExists(t, l.Path("old"))
+	assert.FileExists(t, l.Path("new"))
+	assert.FileExists(t, l.Path("unknown"))
+}
+
+func TestPurgeCompress(t *testing.T) {
+	config := conf.NewConfig()
+	config.LogRotateSize = 200
+	config.LogCompressAfter = time.Hour
+	l := newTestLogger(t, config)
+	createLog(t, l, "job", 10, time.Hour*2)
+
+	require.NoError(t, l.Purge(finishedJobs("job")))
+
+	assert.NoFileExists(t, l.Path("job"))
+	assert.FileExists(t, l.Path("job")+gzipExt)
+	assert.FileExists(t, l.segmentPath("job", 1)+gzipExt)
+	// the compressed log is still streamed
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	logchan, err := l.Tailf(ctx, "job", StreamOptions{})
+	require.NoError(t, err)
+	var records []Output
+	for record := range logchan {
+		records = append(records, record)
+	}
+	require.Len(t, records, 10)
+	for i, record := range records {
+		assert.Equal(t, uint64(i+1), record.Seq)
+	}
+}
+
+func TestPurgeBudget(t *testing.T) {
+	config := conf.NewConfig()
+	l := newTestLogger(t, config)
+	createLog(t, l, "oldest", 10, time.Hour*3)
+	createLog(t, l, "older", 10, time.Hour*2)
+	createLog(t, l, "running", 10, time.Hour*4)
+	// room for two logs
+	for _, name := range []string{"older", "running"} {
+		stat, err := os.Stat(l.Path(name))
+		require.NoError(t, err)
+		l.config.LogFolderBudget += stat.Size()
+	}
+
+	state := func(name string) (bool, bool) {
+		return true, name != "running"
+	}
+	require.NoError(t, l.Purge(state))
+
+	assert.NoFileExists(t, l.Path("oldest"))
+	assert.FileExists(t, l.Path("older"))
+	assert.FileExists(t, l.Path("running"))
+}
@@ -8,99 +8,489 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"sync"
+	"time"
 
 	"github.com/renatoaguimaraes/job-scheduler/pkg/worker/conf"
 )
 
+// errClosed is returned when the log file is closed by the writer
+var errClosed = errors.New("log file closed")
+
+// errFinished is returned when the exit status record is read
+var errFinished = errors.New("log finished")
+
 // Logger implementation.
 type Logger struct {
 	config  conf.Config
 	watcher Watcher
+	mtx     sync.Mutex
+	// writers of the logs being written, by name
+	writers map[string]*Writer
 }
 
 // NewLogger returns a new Logger instance.
-func NewLogger(config conf.Config) Logger {
-	return Logger{
+func NewLogger(config conf.Config) *Logger {
+	return &Logger{
 		config:  config,
-		watcher: NewWatcher(),
+		watcher: NewWatcher(config),
+		writers: make(map[string]*Writer),
 	}
 }
 
+// StreamOptions of a log stream.
+type StreamOptions struct {
+	// Sources stdout and/or stderr, both if empty
+	Sources []Source
+	// Since streams just the records written from this time, if not zero
+	Since time.Time
+	// Offset byte offset of the log to stream from, the end of a record
+	Offset int64
+	// Tail streams just the last records of the log, if not zero, counted
+	// before the sources and time are filtered
+	Tail int
+	// NoFollow streams the log written so far, without following it
+	NoFollow bool
+}
+
+// accept checks if the record is streamed.
+func (o StreamOptions) accept(record Output) bool {
+	if !o.Since.IsZero() && record.Time.Before(o.Since) {
+		return false
+	}
+	if len(o.Sources) == 0 {
+		return true
+	}
+	for _, source := range o.Sources {
+		if record.Source == source {
+			return true
+		}
+	}
+	return false
+}
+
 // Path returns a absolute file path given a name.
 func (l *Logger) Path(name string) string {
 	return filepath.Join(l.config.LogFolder, fmt.Sprintf("%s.log", name))
 }
 
-// Create creates and return a os.File under log folder.
-// If the file can't be created an error will be returned.
-func (l *Logger) Create(name string) (*os.File, error) {
-	return os.Create(l.Path(name))
+// Create creates a log file under log folder, and returns the Writer of its
+// records. The onLimit function is called when the log reaches its maximum
+// size, if the limit action is to kill the job. If the file can't be created
+// an error will be returned.
+func (l *Logger) Create(name string, onLimit func()) (*Writer, error) {
+	file, err := os.Create(l.Path(name))
+	if err != nil {
+		return nil, err
+	}
+	w := newWriter(l, name, file, onLimit)
+	l.mtx.Lock()
+	l.writers[name] = w
+	l.mtx.Unlock()
+	return w, nil
+}
+
+// release forgets the writer of a log once it's closed.
+func (l *Logger) release(w *Writer) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	if l.writers[w.name] == w {
+		delete(l.writers, w.name)
+	}
 }
 
-// Remove removes the named file under the log folder.
+// writer returns the Writer of a log, or nil if the log isn't being written.
+func (l *Logger) writer(name string) *Writer {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	return l.writers[name]
+}
+
+// Remove removes the named file under the log folder, with its rotated
+// and compressed files.
 func (l *Logger) Remove(name string) error {
-	return os.Remove(l.Path(name))
+	err := os.Remove(l.Path(name))
+	files, _ := filepath.Glob(l.Path(name) + ".*")
+	for _, file := range files {
+		if rerr := os.Remove(file); rerr != nil && err == nil {
+			err = rerr
+		}
+	}
+	if os.IsNotExist(err) && len(files) > 0 {
+		return nil
+	}
+	return err
+}
+
+// Open opens a log under the log folder to read its records from a byte
+// offset, the rotated files are read before the current file as a whole.
+// The records written after it's opened may not be read, see Tailf.
+func (l *Logger) Open(name string, offset int64) (io.ReadCloser, error) {
+	paths, err := l.files(name)
+	if err != nil {
+		return nil, err
+	}
+	return newLogReader(paths, offset)
 }
 
-// Tailf watch a named log file under the log folder, and
-// streams his content through a channel.
-func (l *Logger) Tailf(ctx context.Context, name string) (chan string, error) {
-	file, err := os.OpenFile(l.Path(name), os.O_RDONLY, 0644)
+// files returns the files of a log, the rotated files and then the current
+// file, which may be compressed.
+func (l *Logger) files(name string) ([]string, error) {
+	segments, err := l.segments(name)
 	if err != nil {
 		return nil, err
 	}
-	logchan := make(chan string)
+	paths := make([]string, 0, len(segments)+1)
+	for _, segment := range segments {
+		paths = append(paths, segment.path)
+	}
+	current := l.Path(name)
+	if _, err := os.Stat(current); err == nil {
+		paths = append(paths, current)
+	} else if _, gzerr := os.Stat(current + gzipExt); gzerr == nil {
+		// the log of a finished job may be compressed
+		paths = append(paths, current+gzipExt)
+	} else if len(paths) == 0 {
+		return nil, err
+	}
+	return paths, nil
+}
+
+// Tailf watch a named log file under the log folder, and streams his
+// records through a channel, starting from the oldest rotated file.
+func (l *Logger) Tailf(ctx context.Context, name string, options StreamOptions) (chan Output, error) {
+	if _, err := os.Stat(l.Path(name)); err != nil {
+		// the log of a finished job may be compressed
+		if _, gzerr := os.Stat(l.Path(name) + gzipExt); gzerr != nil {
+			return nil, err
+		}
+	}
+	logchan := make(chan Output)
 	go func() {
-		defer func() {
-			if err := file.Close(); err != nil {
-				log.Printf("fail to close the log file: %v", err)
-			}
-			close(logchan)
-		}()
-		// reads file from the begin
-		if err := l.streamFile(ctx, file, logchan); err != nil && err != io.EOF {
+		defer close(logchan)
+		var err error
+		if w := l.writer(name); w != nil && w.buffer != nil && !options.NoFollow {
+			err = l.stream(ctx, name, w.buffer, options, logchan)
+		} else {
+			err = l.tail(ctx, name, options, logchan)
+		}
+		if err != nil && err != io.EOF && err != errFinished {
 			log.Printf("fail to read the log file: %v", err)
-			return
 		}
-		// watching modify and close events
-		eventchan, err := l.watcher.Watch(ctx, l.Path(name))
+	}()
+	return logchan, nil
+}
+
+// stream streams a running log from the records buffered in memory, until
+// the writer closes it. The records which aren't buffered anymore are read
+// from the log files, first and whenever the stream lags behind the buffer.
+func (l *Logger) stream(ctx context.Context, name string, buffer *broadcaster, options StreamOptions, logchan chan Output) error {
+	pos, buffered := buffer.find(options)
+	for {
+		if !buffered {
+			var err error
+			if pos, err = l.catchUp(ctx, name, buffer, &options, logchan); err != nil {
+				return err
+			}
+		}
+		offset, seq, err := buffer.stream(ctx, pos, options, logchan)
+		if err != errLagged {
+			return err
+		}
+		// the records missed are read after the last one read
+		if seq > 0 {
+			options.Offset, options.Tail = offset, 0
+		}
+		buffered = false
+	}
+}
+
+// catchUp streams the records of a running log from the log files, until
+// a buffered record is reached. It returns the position of the buffered
+// record, and updates the options to stream after the last record read.
+func (l *Logger) catchUp(ctx context.Context, name string, buffer *broadcaster, options *StreamOptions, logchan chan Output) (uint64, error) {
+	for {
+		end := buffer.end()
+		first := buffer.firstSeq()
+		filectx, cancel := context.WithCancel(ctx)
+		records := make(chan Output)
+		errchan := make(chan error, 1)
+		go func(options StreamOptions) {
+			defer close(records)
+			errchan <- l.tail(filectx, name, options, records)
+		}(StreamOptions{Offset: options.Offset, Tail: options.Tail, NoFollow: true})
+		var last *Output
+		pos, found, err := uint64(0), false, error(nil)
+		for record := range records {
+			if record.Seq >= first {
+				if pos, found = buffer.position(record.Seq); found {
+					break
+				}
+				first = buffer.firstSeq()
+			}
+			if err = sendRecord(ctx, record, *options, logchan); err != nil {
+				break
+			}
+			last = &record
+		}
+		cancel()
+		for range records {
+		}
+		if terr := <-errchan; err == nil && !found && terr != nil && terr != io.EOF {
+			err = terr
+		}
+		if last != nil {
+			options.Offset, options.Tail = last.Offset, 0
+		}
+		if err != nil || found {
+			return pos, err
+		}
+		if last == nil {
+			// nothing left in the files, the buffer is streamed from now on
+			return end, nil
+		}
+		// the files ended before the buffer, e.g. rotated while read
+		if pos, found = buffer.position(last.Seq + 1); found {
+			return pos, nil
+		}
+	}
+}
+
+// sendRecord sends a record through the channel if the options accept it,
+// the exit status is always sent and errFinished returned after it.
+func sendRecord(ctx context.Context, record Output, options StreamOptions, logchan chan Output) error {
+	if record.Exit == nil && !options.accept(record) {
+		return nil
+	}
+	select {
+	case logchan <- record:
+	case <-ctx.Done():
+		return errors.New("log file stream cancelled")
+	}
+	if record.Exit != nil {
+		return errFinished
+	}
+	return nil
+}
+
+// tail streams the rotated files of a log, and then follows the current file
+// across the rotations, until the writer closes it.
+func (l *Logger) tail(ctx context.Context, name string, options StreamOptions, logchan chan Output) error {
+	start, err := l.startOffset(name, options)
+	if err != nil {
+		return err
+	}
+	// the rotated files from this index are left to stream
+	next := 1
+	// offset of the log where the next file starts
+	var base int64
+	for {
+		segments, err := l.segments(name)
+		if err != nil {
+			return err
+		}
+		// the rotated files aren't written anymore
+		if segment, ok := firstSegment(segments, next); ok {
+			size, err := l.streamSegment(ctx, segment.path, base, start, options, logchan)
+			if err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			base += size
+			next = segment.index + 1
+			continue
+		}
+		file, err := os.Open(l.Path(name))
+		if os.IsNotExist(err) {
+			// the log of a finished job may be compressed
+			if _, gzerr := os.Stat(l.Path(name) + gzipExt); gzerr == nil {
+				_, err := l.streamSegment(ctx, l.Path(name)+gzipExt, base, start, options, logchan)
+				return err
+			}
+			// or rotated just now
+			if segments, serr := l.segments(name); serr == nil {
+				if _, ok := firstSegment(segments, next); ok {
+					continue
+				}
+			}
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		stat, err := file.Stat()
 		if err != nil {
-			log.Printf("fail to watch the log file events: %v", err)
-			return
-		}
-		// reads file changes
-		for {
-			if err := waitForChange(ctx, eventchan); err != nil {
-				log.Printf("%v", err)
-				return
+			file.Close()
+			return err
+		}
+		// the file may be rotated before it's opened, an older rotated file is streamed first
+		if segments, err = l.segments(name); err == nil {
+			if segment, ok := firstSegment(segments, next); ok && !sameFile(segment.path, stat) {
+				file.Close()
+				continue
 			}
-			if err := l.streamFile(ctx, file, logchan); err != nil && err != io.EOF {
-				log.Printf("fail to read the log file: %v", err)
-				return
+		}
+		reader := recordReader{file: file, chunkSize: l.config.LogChunckSize, offset: base}
+		// an offset past the end of the log streams the records written from now on
+		if start > base+stat.Size() {
+			start = base + stat.Size()
+		}
+		var rotated bool
+		err = reader.seek(start)
+		if err == nil {
+			rotated, err = l.follow(ctx, name, &reader, stat, options, logchan)
+		}
+		if err := file.Close(); err != nil {
+			log.Printf("fail to close the log file: %v", err)
+		}
+		if !rotated {
+			return err
+		}
+		base = reader.offset
+		if index, ok := l.segmentIndex(name, stat); ok {
+			next = index + 1
+		}
+	}
+}
+
+// follow streams a log file, like 'tail -f', until the writer closes it. It
+// returns true if the file was rotated, after streaming the rest of it. The
+// file written so far is streamed without following it, if the options say
+// so, or if the log isn't being written, e.g. the log of a previous run.
+func (l *Logger) follow(ctx context.Context, name string, reader *recordReader, stat os.FileInfo, options StreamOptions, logchan chan Output) (bool, error) {
+	var done <-chan struct{}
+	w := l.writer(name)
+	writing := w != nil
+	if writing {
+		done = w.done
+	}
+	// watching modify and close events, before reading so no change is missed
+	watchctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var eventchan chan FileEvent
+	if writing && !options.NoFollow {
+		var err error
+		if eventchan, err = l.watcher.Watch(watchctx, l.Path(name)); err != nil {
+			return false, fmt.Errorf("fail to watch the log file events: %v", err)
+		}
+	}
+	// reads file from the begin
+	if err := l.streamFile(ctx, reader, options, logchan); err != nil && err != io.EOF {
+		return false, err
+	}
+	if !writing || options.NoFollow {
+		return false, nil
+	}
+	// reads file changes
+	for {
+		if l.rotated(name, stat) {
+			// the rest written before the rotation
+			if err := l.streamFile(ctx, reader, options, logchan); err != nil && err != io.EOF {
+				return false, err
 			}
+			return true, nil
 		}
-	}()
-	return logchan, nil
+		err := waitForChange(ctx, eventchan, done)
+		if err != nil && err != errClosed {
+			return false, err
+		}
+		if err := l.streamFile(ctx, reader, options, logchan); err != nil && err != io.EOF {
+			return false, err
+		}
+		// the writer closes the file to rotate it, or when the job is finished
+		if err == errClosed && !l.rotated(name, stat) {
+			return false, nil
+		}
+	}
 }
 
-// streamFile reads chunks from log file given a specific offset and send them throught the channel.
-func (l *Logger) streamFile(ctx context.Context, file *os.File, logchan chan string) error {
+// streamSegment streams a rotated or compressed log file, which starts at the
+// base offset of the log, from the start offset. It returns the file size.
+func (l *Logger) streamSegment(ctx context.Context, path string, base, start int64, options StreamOptions, logchan chan Output) (int64, error) {
+	file, err := openSegment(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	// the uncompressed files before the start aren't read
+	if f, ok := file.(*os.File); ok {
+		stat, err := f.Stat()
+		if err != nil {
+			return 0, err
+		}
+		if base+stat.Size() <= start {
+			return stat.Size(), nil
+		}
+	}
+	reader := recordReader{file: file, chunkSize: l.config.LogChunckSize, offset: base}
+	if err := reader.seek(start); err != nil {
+		return 0, err
+	}
+	if err := l.streamFile(ctx, &reader, options, logchan); err != io.EOF {
+		return 0, err
+	}
+	return reader.offset - base, nil
+}
+
+// startOffset returns the offset of the log to stream from, the later of the
+// offset and the start of the last records, as the options set.
+func (l *Logger) startOffset(name string, options StreamOptions) (int64, error) {
+	if options.Tail <= 0 {
+		return options.Offset, nil
+	}
+	paths, err := l.files(name)
+	if err != nil {
+		return 0, err
+	}
+	sizes := make([]int64, len(paths))
+	var end int64
+	for i, path := range paths {
+		if sizes[i], err = fileSize(path); err != nil {
+			return 0, err
+		}
+		end += sizes[i]
+	}
+	// the last records start after the line break before them, the
+	// exit status isn't counted
+	breaks := options.Tail + 1
+	if len(paths) > 0 {
+		if exit, err := endsWithExit(paths[len(paths)-1]); err == nil && exit {
+			breaks++
+		}
+	}
+	start := int64(0)
+	for i := len(paths) - 1; i >= 0 && breaks > 0; i-- {
+		end -= sizes[i]
+		pos, found, err := lastLineBreak(paths[i], breaks)
+		if err != nil {
+			return 0, err
+		}
+		if found == breaks {
+			start = end + pos + 1
+		}
+		breaks -= found
+	}
+	if start < options.Offset {
+		start = options.Offset
+	}
+	return start, nil
+}
+
+// streamFile reads the records from log file given a specific offset and send them throught the channel.
+// It returns errFinished after the exit status record, which is always streamed.
+func (l *Logger) streamFile(ctx context.Context, reader *recordReader, options StreamOptions, logchan chan Output) error {
 	for {
-		chunck := make([]byte, l.config.LogChunckSize)
-		nbytes, err := file.Read(chunck)
+		record, err := reader.next()
 		if err != nil {
 			return err
 		}
-		select {
-		case logchan <- string(chunck[:nbytes]):
-		case <-ctx.Done():
-			return errors.New("log file stream cancelled")
+		if err := sendRecord(ctx, record, options, logchan); err != nil {
+			return err
 		}
 	}
 }
 
-// waitForChange waits for file system change events and them through the channel.
-func waitForChange(ctx context.Context, eventchan chan FileEvent) error {
+// waitForChange waits for file system change events, or the writer to close the log.
+func waitForChange(ctx context.Context, eventchan chan FileEvent, done <-chan struct{}) error {
 	for {
 		select {
 		case event, ok := <-eventchan:
@@ -111,8 +501,10 @@ func waitForChange(ctx context.Context, eventchan chan FileEvent) error {
 				return nil
 			}
 			if event.Closed() {
-				return errors.New("log file closed")
+				return errClosed
 			}
+		case <-done:
+			return errClosed
 		case <-ctx.Done():
 			return errors.New("log file` watcher cancelled")
 		}
@@ -0,0 +1,142 @@
+package log
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/renatoaguimaraes/job-scheduler/pkg/worker/conf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// streamRecords streams a log written so far.
+func streamRecords(t *testing.T, store LogStore, options StreamOptions) []Output {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	options.NoFollow = true
+	logchan, err := store.Tailf(ctx, "job", options)
+	require.NoError(t, err)
+	var records []Output
+	for record := range logchan {
+		records = append(records, record)
+	}
+	require.NoError(t, ctx.Err())
+	return records
+}
+
+func seqs(records []Output) []uint64 {
+	var seqs []uint64
+	for _, record := range records {
+		seqs = append(seqs, record.Seq)
+	}
+	return seqs
+}
+
+func TestTailfOffset(t *testing.T) {
+	config := conf.NewConfig()
+	config.LogRotateSize = 200
+	l := newTestLogger(t, config)
+	createLog(t, l, "job", 10, 0)
+
+	records := streamRecords(t, l, StreamOptions{})
+	require.Len(t, records, 10)
+	// the offsets count across the rotated files
+	for _, i := range []int{0, 2, 3, 8} {
+		rest := streamRecords(t, l, StreamOptions{Offset: records[i].Offset})
+		assert.Equal(t, seqs(records[i+1:]), seqs(rest))
+	}
+	assert.Empty(t, streamRecords(t, l, StreamOptions{Offset: records[9].Offset}))
+
+	// the compressed files are read as well
+	l.config.LogCompressAfter = time.Nanosecond
+	require.NoError(t, l.Purge(finishedJobs("job")))
+	require.FileExists(t, l.Path("job")+gzipExt)
+	rest := streamRecords(t, l, StreamOptions{Offset: records[4].Offset})
+	assert.Equal(t, seqs(records[5:]), seqs(rest))
+	assert.Equal(t, records[9].Offset, rest[4].Offset)
+}
+
+func TestTailfTail(t *testing.T) {
+	config := conf.NewConfig()
+	config.LogRotateSize = 200
+	l := newTestLogger(t, config)
+	createLog(t, l, "job", 10, 0)
+
+	assert.Equal(t, []uint64{9, 10}, seqs(streamRecords(t, l, StreamOptions{Tail: 2})))
+	assert.Equal(t, []uint64{4, 5, 6, 7, 8, 9, 10}, seqs(streamRecords(t, l, StreamOptions{Tail: 7})))
+	assert.Len(t, streamRecords(t, l, StreamOptions{Tail: 20}), 10)
+	// the later of the offset and the last records
+	records := streamRecords(t, l, StreamOptions{})
+	assert.Equal(t, []uint64{10}, seqs(streamRecords(t, l, StreamOptions{Tail: 5, Offset: records[8].Offset})))
+
+	l.config.LogCompressAfter = time.Nanosecond
+	require.NoError(t, l.Purge(finishedJobs("job")))
+	assert.Equal(t, []uint64{4, 5, 6, 7, 8, 9, 10}, seqs(streamRecords(t, l, StreamOptions{Tail: 7})))
+}
+
+func TestTailfNoFollow(t *testing.T) {
+	l := newTestLogger(t, conf.NewConfig())
+	w, err := l.Create("job", nil)
+	require.NoError(t, err)
+	defer w.Close()
+	writeLines(t, w, 0, 3)
+
+	// the running log is streamed as written so far
+	assert.Equal(t, []uint64{1, 2, 3}, seqs(streamRecords(t, l, StreamOptions{})))
+}
+
+func TestTailfFinished(t *testing.T) {
+	config := conf.NewConfig()
+	config.LogRotateSize = 200
+	l := newTestLogger(t, config)
+	w, err := l.Create("job", nil)
+	require.NoError(t, err)
+	writeLines(t, w, 0, 10)
+	require.NoError(t, w.Finish(Exit{Code: 2, Exited: true}))
+
+	// the stream of a finished log ends with the exit status, even if filtered
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	logchan, err := l.Tailf(ctx, "job", StreamOptions{Sources: []Source{Stderr}})
+	require.NoError(t, err)
+	var records []Output
+	for record := range logchan {
+		records = append(records, record)
+	}
+	require.NoError(t, ctx.Err())
+	require.Len(t, records, 1)
+	assert.Equal(t, uint64(11), records[0].Seq)
+	assert.Equal(t, &Exit{Code: 2, Exited: true}, records[0].Exit)
+}
+
+func TestTailfFinish(t *testing.T) {
+	config := conf.NewConfig()
+	config.LogRotateSize = 200
+	l := newTestLogger(t, config)
+	w, err := l.Create("job", nil)
+	require.NoError(t, err)
+	writeLines(t, w, 0, 3)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	logchan, err := l.Tailf(ctx, "job", StreamOptions{})
+	require.NoError(t, err)
+	// the stream of a running log ends once it's finished
+	go func() {
+		time.Sleep(time.Millisecond * 200)
+		writeLines(t, w, 3, 10)
+		w.Finish(Exit{Code: -1, Termination: "killed", TimedOut: true})
+	}()
+	var records []Output
+	for record := range logchan {
+		records = append(records, record)
+	}
+	require.NoError(t, ctx.Err())
+	require.Len(t, records, 11)
+	assert.Equal(t, []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}, seqs(records))
+	assert.Equal(t, &Exit{Code: -1, Termination: "killed", TimedOut: true}, records[10].Exit)
+	// nothing is written after it
+	assert.Equal(t, os.ErrClosed, w.Close())
+}
@@ -0,0 +1,154 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"time"
+)
+
+// Source of the job output.
+type Source int
+
+const (
+	// Stdout the standard output
+	Stdout Source = iota
+	// Stderr the standard error
+	Stderr
+)
+
+// sourceNames by source, used in the log records
+var sourceNames = map[Source]string{
+	Stdout: "stdout",
+	Stderr: "stderr",
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s Source) MarshalText() ([]byte, error) {
+	name, ok := sourceNames[s]
+	if !ok {
+		return nil, fmt.Errorf("invalid source %d", s)
+	}
+	return []byte(name), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *Source) UnmarshalText(text []byte) error {
+	for source, name := range sourceNames {
+		if name == string(text) {
+			*s = source
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid source %q", text)
+}
+
+// Exit is the exit status of a finished job.
+type Exit struct {
+	// Code exit code of the job, or -1 if it hasn't exited or was
+	// terminated by a signal
+	Code int `json:"code"`
+	// Exited reports whether the job has exited
+	Exited bool `json:"exited"`
+	// Termination how the job was terminated by the worker, if it was
+	Termination string `json:"termination,omitempty"`
+	// TimedOut reports whether the job was stopped by its timeout or deadline
+	TimedOut bool `json:"timedOut,omitempty"`
+	// Signal name of the signal which terminated the job, if any
+	Signal string `json:"signal,omitempty"`
+}
+
+// Output is a log record, a line of the job output. The log file has a
+// record by line in JSON format, and the exit status of the job as the
+// last record, e.g.
+//
+//	{"seq":1,"time":"2021-05-02T17:54:29.123456789-03:00","source":"stdout","data":"done\n"}
+//	{"seq":2,"time":"2021-05-02T17:54:29.125456789-03:00","source":"stdout","data":"","exit":{"code":0,"exited":true}}
+type Output struct {
+	// Seq sequence number of the record in the job log, from one
+	Seq uint64 `json:"seq"`
+	// Time when the output was written
+	Time time.Time `json:"time"`
+	// Source stream of the output
+	Source Source `json:"source"`
+	// Data output line, including the line break, long lines and
+	// lines without a line break for a while are split in records
+	Data string `json:"data"`
+	// Exit status of the job, just in the last record
+	Exit *Exit `json:"exit,omitempty"`
+	// Offset byte offset of the log where the record ends, the
+	// offsets count the log files kept, see Logger.Open
+	Offset int64 `json:"-"`
+}
+
+// recordReader reads the records of a log file, keeping the
+// partial record read so far until the rest of it is written.
+type recordReader struct {
+	file      io.Reader
+	chunkSize int
+	// buf the chunks read, reused once its records are consumed
+	buf []byte
+	// pos of buf where the records left start
+	pos int
+	// offset of the log where the last record read ends
+	offset int64
+}
+
+// next returns the next record, or io.EOF if no whole record is left.
+func (r *recordReader) next() (Output, error) {
+	for {
+		if i := bytes.IndexByte(r.buf[r.pos:], '\n'); i >= 0 {
+			line := r.buf[r.pos : r.pos+i]
+			r.pos += i + 1
+			r.offset += int64(i + 1)
+			var record Output
+			if err := json.Unmarshal(line, &record); err != nil {
+				log.Printf("fail to read the log record: %v", err)
+				continue
+			}
+			record.Offset = r.offset
+			return record, nil
+		}
+		// the partial record left is moved to the begin, and the next
+		// chunk is read after it, growing the buffer just for long records
+		n := copy(r.buf, r.buf[r.pos:])
+		r.buf, r.pos = r.buf[:n], 0
+		if cap(r.buf)-n < r.chunkSize {
+			buf := make([]byte, n, 2*cap(r.buf)+r.chunkSize)
+			copy(buf, r.buf)
+			r.buf = buf
+		}
+		nbytes, err := r.file.Read(r.buf[n : n+r.chunkSize])
+		r.buf = r.buf[:n+nbytes]
+		// the error is returned once the records read are consumed
+		if err != nil && nbytes == 0 {
+			return Output{}, err
+		}
+	}
+}
+
+// seek skips the reader to the start offset of the log, if it's ahead, or
+// to the end of the file if it's shorter. It must be called before the
+// records are read.
+func (r *recordReader) seek(start int64) error {
+	if start <= r.offset {
+		return nil
+	}
+	if seeker, ok := r.file.(io.Seeker); ok {
+		if _, err := seeker.Seek(start-r.offset, io.SeekCurrent); err != nil {
+			return err
+		}
+		r.offset = start
+		return nil
+	}
+	// the compressed files are read
+	n, err := io.CopyN(ioutil.Discard, r.file, start-r.offset)
+	r.offset += n
+	if err == io.EOF {
+		return nil
+	}
+	return err
+}
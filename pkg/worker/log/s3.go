@@ -0,0 +1,268 @@
+package log
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// emptyPayloadHash SHA-256 of an empty request payload
+const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// amzDateFormat time format of the signed requests
+const amzDateFormat = "20060102T150405Z"
+
+const (
+	// s3Timeout maximum time to wait for the response headers of a request, and
+	// for the whole response of the requests other than the uploads and downloads
+	s3Timeout = time.Minute
+	// s3UploadTimeout maximum time of an upload, so an object storage which stops
+	// responding doesn't block the janitor, the downloads are streamed as they're
+	// read instead
+	s3UploadTimeout = time.Minute * 10
+)
+
+// s3Client is a client of a S3 compatible object storage, just for the
+// objects of a bucket, with the path style addressing. The requests are
+// signed with the AWS Signature Version 4.
+type s3Client struct {
+	endpoint  *url.URL
+	bucket    string
+	region    string
+	accessKey string
+	secretKey string
+	client    *http.Client
+	// timeout of the requests, see s3Timeout
+	timeout time.Duration
+	// uploadTimeout of the uploads, see s3UploadTimeout
+	uploadTimeout time.Duration
+}
+
+// newS3Client returns a client of the bucket given the object storage endpoint.
+func newS3Client(endpoint, bucket, region, accessKey, secretKey string) (*s3Client, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid object storage endpoint %q", endpoint)
+	}
+	if bucket == "" {
+		return nil, fmt.Errorf("object storage bucket not set")
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = s3Timeout
+	return &s3Client{
+		endpoint:      u,
+		bucket:        bucket,
+		region:        region,
+		accessKey:     accessKey,
+		secretKey:     secretKey,
+		client:        &http.Client{Transport: transport},
+		timeout:       s3Timeout,
+		uploadTimeout: s3UploadTimeout,
+	}, nil
+}
+
+// put uploads an object, the body size and SHA-256 must be known.
+func (c *s3Client) put(key string, body io.Reader, size int64, payloadHash string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.uploadTimeout)
+	defer cancel()
+	req, err := c.request(ctx, http.MethodPut, key, body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	if size == 0 {
+		req.Body = http.NoBody
+	}
+	res, err := c.do(req, payloadHash)
+	if err != nil {
+		return err
+	}
+	return res.Body.Close()
+}
+
+// get downloads an object from a byte offset.
+func (c *s3Client) get(key string, offset int64) (io.ReadCloser, error) {
+	req, err := c.request(context.Background(), http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	res, err := c.do(req, emptyPayloadHash)
+	if err != nil {
+		return nil, err
+	}
+	return res.Body, nil
+}
+
+// exists checks if an object exists.
+func (c *s3Client) exists(key string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	req, err := c.request(ctx, http.MethodHead, key, nil)
+	if err != nil {
+		return false, err
+	}
+	res, err := c.do(req, emptyPayloadHash)
+	if isNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, res.Body.Close()
+}
+
+// delete deletes an object, deleting an object which doesn't exist succeeds.
+func (c *s3Client) delete(key string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	req, err := c.request(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	res, err := c.do(req, emptyPayloadHash)
+	if err != nil {
+		return err
+	}
+	return res.Body.Close()
+}
+
+// request returns a request of an object of the bucket.
+func (c *s3Client) request(ctx context.Context, method, key string, body io.Reader) (*http.Request, error) {
+	u := *c.endpoint
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + c.bucket + "/" + key
+	return http.NewRequestWithContext(ctx, method, u.String(), body)
+}
+
+// do signs and sends a request, the responses other than 2xx are
+// returned as s3Error.
+func (c *s3Client) do(req *http.Request, payloadHash string) (*http.Response, error) {
+	sign(req, c.region, c.accessKey, c.secretKey, payloadHash, time.Now())
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode/100 != 2 {
+		defer res.Body.Close()
+		message, _ := ioutil.ReadAll(io.LimitReader(res.Body, 1024))
+		return nil, &s3Error{StatusCode: res.StatusCode, Message: strings.TrimSpace(string(message))}
+	}
+	return res, nil
+}
+
+// s3Error is an error response of the object storage.
+type s3Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *s3Error) Error() string {
+	return fmt.Sprintf("object storage request failed with status %d: %s", e.StatusCode, e.Message)
+}
+
+// isNotFound checks if the object doesn't exist.
+func isNotFound(err error) bool {
+	serr, ok := err.(*s3Error)
+	return ok && serr.StatusCode == http.StatusNotFound
+}
+
+// sign signs a request with the AWS Signature Version 4, the host, range
+// and x-amz-* headers are signed.
+// See https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html
+func sign(req *http.Request, region, accessKey, secretKey, payloadHash string, now time.Time) {
+	amzDate := now.UTC().Format(amzDateFormat)
+	scope := amzDate[:8] + "/" + region + "/s3/aws4_request"
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+	headers := map[string]string{"host": host}
+	for name, values := range req.Header {
+		name = strings.ToLower(name)
+		if name == "range" || strings.HasPrefix(name, "x-amz-") {
+			headers[name] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hexSHA256([]byte(canonicalRequest)),
+	}, "\n")
+	key := hmacSHA256([]byte("AWS4"+secretKey), amzDate[:8])
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKey, scope, signedHeaders, signature))
+}
+
+// canonicalQuery returns the query parameters sorted by name.
+func canonicalQuery(query url.Values) string {
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var params []string
+	for _, name := range names {
+		values := query[name]
+		sort.Strings(values)
+		for _, value := range values {
+			params = append(params, uriEncode(name)+"="+uriEncode(value))
+		}
+	}
+	return strings.Join(params, "&")
+}
+
+// uriEncode encodes a query parameter as the AWS Signature Version 4 requires.
+func uriEncode(value string) string {
+	return strings.ReplaceAll(url.QueryEscape(value), "+", "%20")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func hexSHA256(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,358 @@
+package log
+
+import (
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// gzipExt extension of the compressed log files
+const gzipExt = ".gz"
+
+// segment is a rotated log file, named after the current log file
+// followed by its index, e.g. <job id>.log.1 is the oldest one.
+type segment struct {
+	index int
+	path  string
+}
+
+// segmentPath returns the path of a rotated log file.
+func (l *Logger) segmentPath(name string, index int) string {
+	return l.Path(name) + "." + strconv.Itoa(index)
+}
+
+// segments returns the rotated files of a log, the oldest first.
+func (l *Logger) segments(name string) ([]segment, error) {
+	prefix := l.Path(name) + "."
+	paths, err := filepath.Glob(prefix + "*")
+	if err != nil {
+		return nil, err
+	}
+	var segments []segment
+	for _, path := range paths {
+		index, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(path, prefix), gzipExt))
+		if err != nil {
+			continue
+		}
+		segments = append(segments, segment{index: index, path: path})
+	}
+	sort.Slice(segments, func(i, j int) bool {
+		return segments[i].index < segments[j].index
+	})
+	return segments, nil
+}
+
+// segmentIndex returns the index of the rotated log file, if it was rotated.
+func (l *Logger) segmentIndex(name string, stat os.FileInfo) (int, bool) {
+	segments, err := l.segments(name)
+	if err != nil {
+		return 0, false
+	}
+	for _, segment := range segments {
+		if sameFile(segment.path, stat) {
+			return segment.index, true
+		}
+	}
+	return 0, false
+}
+
+// rotated checks if the log file was rotated, once the new current file
+// is created, which happens before the rotated file is closed.
+func (l *Logger) rotated(name string, stat os.FileInfo) bool {
+	current, err := os.Stat(l.Path(name))
+	return err == nil && !os.SameFile(current, stat)
+}
+
+// firstSegment returns the oldest rotated file from the index.
+func firstSegment(segments []segment, index int) (segment, bool) {
+	for _, segment := range segments {
+		if segment.index >= index {
+			return segment, true
+		}
+	}
+	return segment{}, false
+}
+
+// sameFile checks if the path is the file.
+func sameFile(path string, stat os.FileInfo) bool {
+	other, err := os.Stat(path)
+	return err == nil && os.SameFile(other, stat)
+}
+
+// gzipFile reads a compressed log file.
+type gzipFile struct {
+	*gzip.Reader
+	file *os.File
+}
+
+// Close closes the compressed log file.
+func (f *gzipFile) Close() error {
+	f.Reader.Close()
+	return f.file.Close()
+}
+
+// openSegment opens a rotated or compressed log file.
+func openSegment(path string) (io.ReadCloser, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(path, gzipExt) {
+		return file, nil
+	}
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &gzipFile{Reader: reader, file: file}, nil
+}
+
+// logReader reads the files of a log, one after the other.
+type logReader struct {
+	paths []string
+	file  io.ReadCloser
+}
+
+// newLogReader returns a reader of the log files from a byte offset, the
+// uncompressed files before the offset aren't read.
+func newLogReader(paths []string, offset int64) (*logReader, error) {
+	r := &logReader{paths: paths}
+	for offset > 0 && len(r.paths) > 0 {
+		path := r.paths[0]
+		if strings.HasSuffix(path, gzipExt) {
+			// the compressed files are skipped by reading them
+			break
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		stat, err := file.Stat()
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		if offset >= stat.Size() {
+			file.Close()
+			offset -= stat.Size()
+			r.paths = r.paths[1:]
+			continue
+		}
+		if _, err := file.Seek(offset, io.SeekStart); err != nil {
+			file.Close()
+			return nil, err
+		}
+		r.file = file
+		r.paths = r.paths[1:]
+		return r, nil
+	}
+	if _, err := io.CopyN(ioutil.Discard, r, offset); err != nil && err != io.EOF {
+		r.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+// Read implements io.Reader, the files removed meanwhile are skipped.
+func (r *logReader) Read(p []byte) (int, error) {
+	for {
+		if r.file == nil {
+			if len(r.paths) == 0 {
+				return 0, io.EOF
+			}
+			file, err := openSegment(r.paths[0])
+			r.paths = r.paths[1:]
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				return 0, err
+			}
+			r.file = file
+		}
+		n, err := r.file.Read(p)
+		if err == io.EOF {
+			r.file.Close()
+			r.file = nil
+			if n == 0 {
+				continue
+			}
+			err = nil
+		}
+		return n, err
+	}
+}
+
+// Close closes the file being read.
+func (r *logReader) Close() error {
+	if r.file == nil {
+		return nil
+	}
+	return r.file.Close()
+}
+
+// fileSize returns the size of a log file, the compressed files are read
+// to know their uncompressed size.
+func fileSize(path string) (int64, error) {
+	if !strings.HasSuffix(path, gzipExt) {
+		stat, err := os.Stat(path)
+		if err != nil {
+			return 0, err
+		}
+		return stat.Size(), nil
+	}
+	file, err := openSegment(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	return io.Copy(ioutil.Discard, file)
+}
+
+// endsWithExit checks if the last record of a log file is the exit status.
+func endsWithExit(path string) (bool, error) {
+	pos, found, err := lastLineBreak(path, 2)
+	if err != nil {
+		return false, err
+	}
+	var start int64
+	if found == 2 {
+		start = pos + 1
+	}
+	reader, err := newLogReader([]string{path}, start)
+	if err != nil {
+		return false, err
+	}
+	defer reader.Close()
+	records := recordReader{file: reader, chunkSize: 4096}
+	var last Output
+	for {
+		record, err := records.next()
+		if err == io.EOF {
+			return last.Exit != nil, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		last = record
+	}
+}
+
+// lastLineBreak finds the nth last line break of a log file. It returns its
+// position, if found, and the number of line breaks found, up to n.
+func lastLineBreak(path string, n int) (int64, int, error) {
+	if strings.HasSuffix(path, gzipExt) {
+		return lastLineBreakCompressed(path, n)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer file.Close()
+	stat, err := file.Stat()
+	if err != nil {
+		return 0, 0, err
+	}
+	// the file is read backwards
+	buf := make([]byte, 4096)
+	found := 0
+	for end := stat.Size(); end > 0; {
+		start := end - int64(len(buf))
+		if start < 0 {
+			start = 0
+		}
+		chunk := buf[:end-start]
+		if _, err := file.ReadAt(chunk, start); err != nil {
+			return 0, found, err
+		}
+		for i := len(chunk) - 1; i >= 0; i-- {
+			if chunk[i] != '\n' {
+				continue
+			}
+			if found++; found == n {
+				return start + int64(i), found, nil
+			}
+		}
+		end = start
+	}
+	return 0, found, nil
+}
+
+// lastLineBreakCompressed finds the nth last line break of a compressed log
+// file, which is read from the begin.
+func lastLineBreakCompressed(path string, n int) (int64, int, error) {
+	file, err := openSegment(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer file.Close()
+	// the positions of the last line breaks
+	last := make([]int64, n)
+	count := 0
+	buf := make([]byte, 4096)
+	var offset int64
+	for {
+		nbytes, err := file.Read(buf)
+		for i := 0; i < nbytes; i++ {
+			if buf[i] == '\n' {
+				last[count%n] = offset + int64(i)
+				count++
+			}
+		}
+		offset += int64(nbytes)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	if count < n {
+		return 0, count, nil
+	}
+	return last[count%n], n, nil
+}
+
+// compress compresses a log file, keeping its modification time.
+func compress(path string) error {
+	stat, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	// the compressed file is renamed when complete, so it's never read partially
+	tmp := path + gzipExt + ".tmp"
+	out, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	writer := gzip.NewWriter(out)
+	_, err = io.Copy(writer, file)
+	if cerr := writer.Close(); err == nil {
+		err = cerr
+	}
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Chtimes(tmp, stat.ModTime(), stat.ModTime())
+	}
+	if err == nil {
+		err = os.Rename(tmp, path+gzipExt)
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Remove(path)
+}
@@ -0,0 +1,51 @@
+package log
+
+import (
+	"context"
+	"io"
+
+	"github.com/renatoaguimaraes/job-scheduler/pkg/worker/conf"
+)
+
+// LogStore stores the job logs, the Logger stores them in the local log
+// folder, and the ArchiveStore archives the logs of the finished jobs in
+// a S3 compatible object storage as well.
+type LogStore interface {
+	// Create creates the log of a job.
+	//    - name: log name, the job identifier
+	//    - onLimit: called when the log reaches its maximum size, if the
+	//      limit action is to kill the job
+	// It returns the Writer appending the job output records to the log,
+	// and the execution error encountered.
+	Create(name string, onLimit func()) (*Writer, error)
+	// Open opens a log to read its records.
+	//    - name: log name
+	//    - offset: byte offset to read from
+	// It returns the log content reader and the execution error encountered.
+	Open(name string, offset int64) (io.ReadCloser, error)
+	// Tailf streams the log records, and follows the log until it's closed.
+	//    - ctx: context to cancel the log stream
+	//    - name: log name
+	//    - options: records streamed
+	// It returns a chan to stream the records and the execution error
+	// encountered.
+	Tailf(ctx context.Context, name string, options StreamOptions) (chan Output, error)
+	// Remove removes a log.
+	//    - name: log name
+	// It returns the execution error encountered.
+	Remove(name string) error
+	// Purge compresses, archives and deletes the logs of the finished jobs.
+	//    - state: state of the job of a log
+	// It returns the execution error encountered.
+	Purge(state JobState) error
+}
+
+// NewLogStore returns the LogStore of the configuration, the ArchiveStore if
+// a log archive is set, otherwise the Logger.
+func NewLogStore(config conf.Config) (LogStore, error) {
+	logger := NewLogger(config)
+	if config.LogArchiveEndpoint == "" {
+		return logger, nil
+	}
+	return NewArchiveStore(logger, config)
+}
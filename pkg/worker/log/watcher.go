@@ -1,8 +1,13 @@
 package log
 
-import "context"
+import (
+	"context"
+	"log"
 
-// FileEvent wrap an inotify event.
+	"github.com/renatoaguimaraes/job-scheduler/pkg/worker/conf"
+)
+
+// FileEvent wrap a file system event, from inotify or polling.
 type FileEvent interface {
 	// Modified returns true if the file was changed.
 	Modified() bool
@@ -15,3 +20,36 @@ type Watcher interface {
 	// Watch monitoring file system changes and send the events throught a channel.
 	Watch(ctx context.Context, path string) (eventchan chan FileEvent, err error)
 }
+
+// NewWatcher returns the Watcher of the configuration, see conf.Config.
+func NewWatcher(config conf.Config) Watcher {
+	switch config.LogWatcher {
+	case conf.LogWatcherInotify:
+		return newInotifyWatcher()
+	case conf.LogWatcherPoll:
+		return newPollWatcher(config.LogPollInterval)
+	default:
+		return &autoWatcher{
+			inotify: newInotifyWatcher(),
+			poll:    newPollWatcher(config.LogPollInterval),
+		}
+	}
+}
+
+// autoWatcher watches the files with inotify, and falls back to polling
+// them when inotify fails, e.g. not supported by the file system or out
+// of inotify instances.
+type autoWatcher struct {
+	inotify Watcher
+	poll    Watcher
+}
+
+// Watch watches a file with inotify, or polls it.
+func (w *autoWatcher) Watch(ctx context.Context, path string) (chan FileEvent, error) {
+	eventchan, err := w.inotify.Watch(ctx, path)
+	if err == nil {
+		return eventchan, nil
+	}
+	log.Printf("fail to watch the log file with inotify, polling it: %v", err)
+	return w.poll.Watch(ctx, path)
+}
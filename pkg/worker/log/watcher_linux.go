@@ -3,6 +3,7 @@ package log
 import (
 	"context"
 	"log"
+	"os"
 	"syscall"
 	"unsafe"
 )
@@ -16,9 +17,10 @@ func (e *fileLinuxEvent) Modified() bool {
 	return e.mask&syscall.IN_MODIFY == syscall.IN_MODIFY
 }
 
-// Closed returns true if the event is syscall.IN_CLOSE.
+// Closed returns true if the event is syscall.IN_CLOSE_WRITE, the readers
+// closing the file are ignored.
 func (e *fileLinuxEvent) Closed() bool {
-	return e.mask&syscall.IN_CLOSE == syscall.IN_CLOSE
+	return e.mask&syscall.IN_CLOSE_WRITE == syscall.IN_CLOSE_WRITE
 }
 
 // wrapLinuxEvent create a new File event given an event mask.
@@ -33,46 +35,58 @@ type linuxWatcher struct {
 	mask uint32
 }
 
-// NewWatcher instance
-func NewWatcher() Watcher {
+// newInotifyWatcher returns a Watcher of the inotify events.
+func newInotifyWatcher() Watcher {
 	return &linuxWatcher{
 		mask: syscall.IN_MODIFY | syscall.IN_CLOSE,
 	}
 }
 
-// Watcher watch file system events and send them throught a channel.
+// Watcher watch file system events and send them throught a channel. The
+// inotify instance is non-blocking, so its reads are woken up and its file
+// descriptor closed as soon as the context is cancelled.
 // See https://linux.die.net/man/1/inotifywait
 func (w *linuxWatcher) Watch(ctx context.Context, path string) (chan FileEvent, error) {
-	filed, err := syscall.InotifyInit()
+	filed, err := syscall.InotifyInit1(syscall.IN_NONBLOCK | syscall.IN_CLOEXEC)
 	if err != nil {
 		return nil, err
 	}
 	// watching for file system events given a file path
-	watched, err := syscall.InotifyAddWatch(filed, path, w.mask)
-	if err != nil {
+	if _, err := syscall.InotifyAddWatch(filed, path, w.mask); err != nil {
 		fderr := syscall.Close(filed)
 		if fderr != nil {
 			log.Printf("Fail to close file descriptor: %v", fderr)
 		}
 		return nil, err
 	}
+	// the runtime poller waits for the events, the watch is removed
+	// when the file is closed
+	file := os.NewFile(uintptr(filed), path)
 	eventchan := make(chan FileEvent)
+	stopped := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-stopped:
+		}
+		if err := file.Close(); err != nil {
+			log.Printf("Fail to close file descriptor: %v", err)
+		}
+	}()
 	go func() {
-		// remove watched from the watch list
 		defer func() {
-			success, err := syscall.InotifyRmWatch(filed, uint32(watched))
-			if success == -1 || err != nil {
-				log.Printf("Fail to remove the file watch: %v", err)
-			}
+			close(stopped)
 			close(eventchan)
 		}()
 		// buffer to store events sent by OS
 		buf := make([]byte, syscall.SizeofInotifyEvent*4096)
 		for {
 			// read events from file descriptor and fill the event buffer
-			nbytes, err := syscall.Read(filed, buf[:])
+			nbytes, err := file.Read(buf[:])
 			if err != nil {
-				log.Printf("Fail to read events: %v", err)
+				if ctx.Err() == nil {
+					log.Printf("Fail to read events: %v", err)
+				}
 				return
 			}
 			// iterate over all buffered events and send them through the channel
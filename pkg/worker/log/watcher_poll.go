@@ -0,0 +1,84 @@
+package log
+
+import (
+	"context"
+	"os"
+	"time"
+)
+
+// defaultPollInterval interval to poll the files, if not set.
+const defaultPollInterval = time.Millisecond * 250
+
+// pollEvent is a change of a polled file, the polling can't tell when the
+// file is closed.
+type pollEvent struct{}
+
+// Modified returns true, the file size, modification time or inode changed.
+func (e pollEvent) Modified() bool {
+	return true
+}
+
+// Closed returns false.
+func (e pollEvent) Closed() bool {
+	return false
+}
+
+// pollWatcher Watcher implementation which polls the file status, for the
+// file systems which don't report their changes to inotify, e.g. NFS or FUSE.
+type pollWatcher struct {
+	interval time.Duration
+}
+
+// newPollWatcher returns a Watcher which polls the files at an interval,
+// or at the default interval if it's not positive.
+func newPollWatcher(interval time.Duration) Watcher {
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	return &pollWatcher{interval: interval}
+}
+
+// Watch polls the file status at the interval and sends an event through
+// the channel when it changes, a file replaced by another one as well.
+func (w *pollWatcher) Watch(ctx context.Context, path string) (chan FileEvent, error) {
+	stat, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	eventchan := make(chan FileEvent)
+	go func() {
+		defer close(eventchan)
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+			next, err := os.Stat(path)
+			if err != nil {
+				// removed or renamed, e.g. rotated, until it's created again
+				next = nil
+			}
+			if !changed(stat, next) {
+				continue
+			}
+			stat = next
+			select {
+			case eventchan <- pollEvent{}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return eventchan, nil
+}
+
+// changed checks if the file status changed, nil if the file doesn't exist.
+func changed(stat, next os.FileInfo) bool {
+	if stat == nil || next == nil {
+		return stat != next
+	}
+	return stat.Size() != next.Size() || !stat.ModTime().Equal(next.ModTime()) || !os.SameFile(stat, next)
+}
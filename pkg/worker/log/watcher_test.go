@@ -0,0 +1,150 @@
+package log
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/renatoaguimaraes/job-scheduler/pkg/worker/conf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func waitEvent(t *testing.T, eventchan chan FileEvent) FileEvent {
+	select {
+	case event := <-eventchan:
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("no file event")
+		return nil
+	}
+}
+
+func TestWatcherCancel(t *testing.T) {
+	l := newTestLogger(t, conf.NewConfig())
+	w, err := l.Create("job", nil)
+	require.NoError(t, err)
+	defer w.Close()
+	before := openFiles(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	eventchan, err := l.watcher.Watch(ctx, l.Path("job"))
+	require.NoError(t, err)
+	// the watcher stops without any event
+	cancel()
+	select {
+	case _, ok := <-eventchan:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("watcher not stopped")
+	}
+	// and its file descriptor doesn't leak
+	time.Sleep(time.Millisecond * 100)
+	assert.LessOrEqual(t, openFiles(t), before)
+}
+
+func openFiles(t *testing.T) int {
+	files, err := ioutil.ReadDir("/proc/self/fd")
+	require.NoError(t, err)
+	return len(files)
+}
+
+func TestPollWatcher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "job.log")
+	require.NoError(t, ioutil.WriteFile(path, []byte("line 0\n"), 0644))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	eventchan, err := newPollWatcher(time.Millisecond*10).Watch(ctx, path)
+	require.NoError(t, err)
+	// appended
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	_, err = file.WriteString("line 1\n")
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	assert.True(t, waitEvent(t, eventchan).Modified())
+	// replaced by a new file of the same size, e.g. rotated
+	require.NoError(t, os.Rename(path, path+".1"))
+	require.NoError(t, ioutil.WriteFile(path, []byte("line 2\nline 3\n"), 0644))
+	assert.True(t, waitEvent(t, eventchan).Modified())
+
+	cancel()
+	for range eventchan {
+	}
+	// the missing files can't be watched
+	_, err = newPollWatcher(time.Millisecond*10).Watch(context.Background(), path+".2")
+	assert.True(t, os.IsNotExist(err))
+}
+
+// failingWatcher is a Watcher which always fails, like inotify on a file
+// system which doesn't support it.
+type failingWatcher struct{}
+
+func (w failingWatcher) Watch(ctx context.Context, path string) (chan FileEvent, error) {
+	return nil, errors.New("inotify not supported")
+}
+
+func TestAutoWatcher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "job.log")
+	require.NoError(t, ioutil.WriteFile(path, nil, 0644))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// falls back to polling
+	w := &autoWatcher{inotify: failingWatcher{}, poll: newPollWatcher(time.Millisecond * 10)}
+	eventchan, err := w.Watch(ctx, path)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(path, []byte("line 0\n"), 0644))
+	assert.True(t, waitEvent(t, eventchan).Modified())
+}
+
+func TestPollWatcherDefaultInterval(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "job.log")
+	require.NoError(t, ioutil.WriteFile(path, nil, 0644))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// a configuration literal doesn't set the poll interval
+	eventchan, err := NewWatcher(conf.Config{LogWatcher: conf.LogWatcherPoll}).Watch(ctx, path)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(path, []byte("line 0\n"), 0644))
+	assert.True(t, waitEvent(t, eventchan).Modified())
+}
+
+func TestTailfPoll(t *testing.T) {
+	config := conf.NewConfig()
+	config.LogRotateSize = 200
+	config.LogBufferSize = 0
+	config.LogWatcher = conf.LogWatcherPoll
+	config.LogPollInterval = time.Millisecond * 10
+	l := newTestLogger(t, config)
+	w, err := l.Create("job", nil)
+	require.NoError(t, err)
+	writeLines(t, w, 0, 5)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	// the polled log files are followed across the rotations
+	logchan, err := l.Tailf(ctx, "job", StreamOptions{})
+	require.NoError(t, err)
+	go func() {
+		for i := 5; i < 20; i++ {
+			time.Sleep(time.Millisecond * 20)
+			writeLines(t, w, i, i+1)
+		}
+		w.Finish(Exit{Exited: true})
+	}()
+	var records []Output
+	for record := range logchan {
+		records = append(records, record)
+	}
+	require.NoError(t, ctx.Err())
+	require.Len(t, records, 21)
+	for i, record := range records {
+		assert.Equal(t, uint64(i+1), record.Seq)
+	}
+}
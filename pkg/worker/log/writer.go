@@ -0,0 +1,316 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/renatoaguimaraes/job-scheduler/pkg/worker/conf"
+)
+
+// flushDelay is how long a partial line is kept waiting for the rest of
+// it, so prompts and progress output are written without a line break
+const flushDelay = time.Millisecond * 100
+
+// maxRecordSize maximum size in bytes of the record data, longer lines are split
+const maxRecordSize = 16 * 1024
+
+// Writer frames the job output into log records, a record by line, with a
+// sequence number shared by the stdout and stderr. The log file is rotated
+// when it reaches the rotation size, and the maximum size of the job log is
+// enforced, see conf.Config. It's safe for concurrent use.
+type Writer struct {
+	mtx     sync.Mutex
+	logger  *Logger
+	name    string
+	file    *os.File
+	buf     bytes.Buffer
+	encoder *json.Encoder
+	seq     uint64
+	closed  bool
+	streams map[Source]*streamWriter
+	// size of the current log file
+	size int64
+	// rotated files kept, the oldest first
+	rotated []rotatedFile
+	// index of the last rotated file
+	index int
+	// onLimit is called when the maximum size is reached
+	onLimit func()
+	// limited the maximum size was reached and the job killed
+	limited bool
+	// done is closed when the log file is closed
+	done chan struct{}
+	// buffer of the last records for the streams, if configured
+	buffer *broadcaster
+}
+
+// rotatedFile is a rotated log file kept by the Writer.
+type rotatedFile struct {
+	index int
+	size  int64
+}
+
+// newWriter returns a Writer of the records to the file.
+func newWriter(logger *Logger, name string, file *os.File, onLimit func()) *Writer {
+	w := &Writer{
+		logger:  logger,
+		name:    name,
+		file:    file,
+		streams: make(map[Source]*streamWriter),
+		onLimit: onLimit,
+		done:    make(chan struct{}),
+	}
+	if size := logger.config.LogBufferSize; size > 0 {
+		w.buffer = newBroadcaster(size)
+	}
+	w.encoder = json.NewEncoder(&w.buf)
+	w.encoder.SetEscapeHTML(false)
+	for source := range sourceNames {
+		w.streams[source] = &streamWriter{writer: w, source: source}
+	}
+	return w
+}
+
+// Stream returns the writer of a job output stream.
+func (w *Writer) Stream(source Source) io.Writer {
+	return w.streams[source]
+}
+
+// Close writes the partial lines left and closes the log file.
+func (w *Writer) Close() error {
+	return w.close(nil)
+}
+
+// Finish writes the partial lines left and the exit status of the job as
+// the last record, and closes the log file. The log streams end there.
+func (w *Writer) Finish(exit Exit) error {
+	return w.close(&exit)
+}
+
+// close closes the log file, writing the exit status if any.
+func (w *Writer) close(exit *Exit) error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	if w.closed {
+		return os.ErrClosed
+	}
+	var err error
+	for _, stream := range w.streams {
+		if ferr := stream.flush(len(stream.partial)); err == nil {
+			err = ferr
+		}
+	}
+	if exit != nil {
+		if eerr := w.writeExit(*exit); err == nil {
+			err = eerr
+		}
+	}
+	w.closed = true
+	if cerr := w.file.Close(); err == nil {
+		err = cerr
+	}
+	if w.buffer != nil {
+		w.buffer.close()
+	}
+	w.logger.release(w)
+	close(w.done)
+	return err
+}
+
+// write writes a record. It must be called holding the lock. The records
+// over the maximum size are dropped, so the sequence numbers skip them.
+func (w *Writer) write(source Source, data []byte) error {
+	w.seq++
+	record := Output{
+		Seq:    w.seq,
+		Time:   time.Now(),
+		Source: source,
+		Data:   string(data),
+	}
+	w.buf.Reset()
+	if err := w.encoder.Encode(record); err != nil {
+		return err
+	}
+	size := int64(w.buf.Len())
+	if !w.reserve(size) {
+		return nil
+	}
+	if err := w.append(record); err != nil {
+		return err
+	}
+	if rotateSize := w.logger.config.LogRotateSize; rotateSize > 0 && w.size >= rotateSize {
+		// the log keeps growing if it can't be rotated
+		if err := w.rotate(); err != nil {
+			log.Printf("fail to rotate the log file: %v", err)
+		}
+	}
+	return nil
+}
+
+// writeExit writes the exit status record, which is never dropped nor
+// rotated, so it's always the last record of the current file. It must
+// be called holding the lock.
+func (w *Writer) writeExit(exit Exit) error {
+	w.seq++
+	record := Output{
+		Seq:  w.seq,
+		Time: time.Now(),
+		Exit: &exit,
+	}
+	w.buf.Reset()
+	if err := w.encoder.Encode(record); err != nil {
+		return err
+	}
+	return w.append(record)
+}
+
+// append writes the encoded record to the log file, and publishes it to the
+// streams. It must be called holding the lock.
+func (w *Writer) append(record Output) error {
+	start := w.total()
+	if _, err := w.file.Write(w.buf.Bytes()); err != nil {
+		return err
+	}
+	w.size += int64(w.buf.Len())
+	if w.buffer != nil {
+		record.Offset = start + int64(w.buf.Len())
+		w.buffer.publish(record, start)
+	}
+	return nil
+}
+
+// reserve checks if a record fits in the maximum size of the log. The oldest
+// rotated files are removed to make room, or the job is killed, as the limit
+// action. It must be called holding the lock.
+func (w *Writer) reserve(size int64) bool {
+	maxSize := w.logger.config.LogMaxSize
+	if maxSize <= 0 {
+		return true
+	}
+	if w.logger.config.LogLimitAction == conf.LogLimitKill {
+		if !w.limited && w.total()+size > maxSize {
+			w.limited = true
+			if w.onLimit != nil {
+				go w.onLimit()
+			}
+		}
+		return !w.limited
+	}
+	for w.total()+size > maxSize && len(w.rotated) > 0 {
+		oldest := w.rotated[0]
+		if err := os.Remove(w.logger.segmentPath(w.name, oldest.index)); err != nil && !os.IsNotExist(err) {
+			log.Printf("fail to remove the rotated log file: %v", err)
+			break
+		}
+		w.rotated = w.rotated[1:]
+	}
+	return w.total()+size <= maxSize
+}
+
+// total returns the size of the log, including the rotated files.
+// It must be called holding the lock.
+func (w *Writer) total() int64 {
+	total := w.size
+	for _, file := range w.rotated {
+		total += file.size
+	}
+	return total
+}
+
+// rotate renames the current log file to the next rotated file, and
+// creates a new current file. It must be called holding the lock.
+func (w *Writer) rotate() error {
+	path := w.logger.Path(w.name)
+	rotatedPath := w.logger.segmentPath(w.name, w.index+1)
+	if err := os.Rename(path, rotatedPath); err != nil {
+		return err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		// keeps writing the rotated file
+		os.Rename(rotatedPath, path)
+		return err
+	}
+	w.index++
+	w.rotated = append(w.rotated, rotatedFile{index: w.index, size: w.size})
+	w.size = 0
+	// the readers follow the new file once the rotated one is closed
+	old := w.file
+	w.file = file
+	return old.Close()
+}
+
+// streamWriter writes the output of a stream as log records.
+type streamWriter struct {
+	writer  *Writer
+	source  Source
+	partial []byte
+	timer   *time.Timer
+}
+
+// Write implements io.Writer, the whole lines are written as records right
+// away, while a partial line is written if the rest doesn't come soon.
+func (s *streamWriter) Write(p []byte) (int, error) {
+	s.writer.mtx.Lock()
+	defer s.writer.mtx.Unlock()
+	if s.writer.closed {
+		return 0, os.ErrClosed
+	}
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+	s.partial = append(s.partial, p...)
+	for {
+		n := bytes.IndexByte(s.partial, '\n') + 1
+		if n == 0 && len(s.partial) < maxRecordSize {
+			break
+		}
+		if n == 0 || n > maxRecordSize {
+			n = runeBoundary(s.partial, maxRecordSize)
+		}
+		if err := s.flush(n); err != nil {
+			return 0, err
+		}
+	}
+	if len(s.partial) > 0 {
+		s.timer = time.AfterFunc(flushDelay, func() {
+			s.writer.mtx.Lock()
+			defer s.writer.mtx.Unlock()
+			if !s.writer.closed {
+				s.flush(runeBoundary(s.partial, len(s.partial)))
+			}
+		})
+	}
+	return len(p), nil
+}
+
+// flush writes the first n bytes of the partial line as a record.
+// It must be called holding the lock.
+func (s *streamWriter) flush(n int) error {
+	if n == 0 {
+		return nil
+	}
+	err := s.writer.write(s.source, s.partial[:n])
+	s.partial = append(s.partial[:0], s.partial[n:]...)
+	return err
+}
+
+// runeBoundary returns the length of the longest prefix of data, up to n
+// bytes, which doesn't end in the middle of a UTF-8 encoded rune.
+func runeBoundary(data []byte, n int) int {
+	for i := n; i > 0 && i > n-utf8.UTFMax; i-- {
+		if utf8.RuneStart(data[i-1]) {
+			if utf8.FullRune(data[i-1 : n]) {
+				return n
+			}
+			return i - 1
+		}
+	}
+	return n
+}
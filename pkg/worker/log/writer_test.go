@@ -0,0 +1,217 @@
+package log
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/renatoaguimaraes/job-scheduler/pkg/worker/conf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLogger(t *testing.T, config conf.Config) *Logger {
+	config.LogFolder = t.TempDir()
+	return NewLogger(config)
+}
+
+func readRecords(t *testing.T, path string) []Output {
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	var records []Output
+	decoder := json.NewDecoder(file)
+	for decoder.More() {
+		var record Output
+		require.NoError(t, decoder.Decode(&record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestWriterLines(t *testing.T) {
+	l := newTestLogger(t, conf.NewConfig())
+	w, err := l.Create("job", nil)
+	require.NoError(t, err)
+	path := l.Path("job")
+
+	_, err = w.Stream(Stdout).Write([]byte("first\nsec"))
+	require.NoError(t, err)
+	_, err = w.Stream(Stderr).Write([]byte("error\n"))
+	require.NoError(t, err)
+	_, err = w.Stream(Stdout).Write([]byte("ond\nlast"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	records := readRecords(t, path)
+	require.Len(t, records, 4)
+	for i, record := range records {
+		assert.Equal(t, uint64(i+1), record.Seq)
+		assert.False(t, record.Time.IsZero())
+	}
+	assert.Equal(t, Output{Seq: 1, Time: records[0].Time, Source: Stdout, Data: "first\n"}, records[0])
+	assert.Equal(t, Output{Seq: 2, Time: records[1].Time, Source: Stderr, Data: "error\n"}, records[1])
+	assert.Equal(t, Output{Seq: 3, Time: records[2].Time, Source: Stdout, Data: "second\n"}, records[2])
+	assert.Equal(t, Output{Seq: 4, Time: records[3].Time, Source: Stdout, Data: "last"}, records[3])
+}
+
+func TestWriterLongLine(t *testing.T) {
+	l := newTestLogger(t, conf.NewConfig())
+	w, err := l.Create("job", nil)
+	require.NoError(t, err)
+	path := l.Path("job")
+
+	// the line is split out of the multi-byte runes
+	line := strings.Repeat("é", maxRecordSize) + "\n"
+	_, err = w.Stream(Stdout).Write([]byte(line))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	var data strings.Builder
+	for _, record := range readRecords(t, path) {
+		assert.LessOrEqual(t, len(record.Data), maxRecordSize)
+		assert.True(t, strings.HasSuffix(record.Data, "é") || strings.HasSuffix(record.Data, "\n"))
+		data.WriteString(record.Data)
+	}
+	assert.Equal(t, line, data.String())
+}
+
+func TestWriterPartialLine(t *testing.T) {
+	l := newTestLogger(t, conf.NewConfig())
+	w, err := l.Create("job", nil)
+	require.NoError(t, err)
+	path := l.Path("job")
+	defer w.Close()
+
+	_, err = w.Stream(Stdout).Write([]byte("password: "))
+	require.NoError(t, err)
+	assert.Empty(t, readRecords(t, path))
+
+	time.Sleep(flushDelay * 2)
+
+	records := readRecords(t, path)
+	require.Len(t, records, 1)
+	assert.Equal(t, "password: ", records[0].Data)
+}
+
+func writeLines(t *testing.T, w *Writer, from, to int) {
+	for i := from; i < to; i++ {
+		_, err := w.Stream(Stdout).Write([]byte(fmt.Sprintf("line %d\n", i)))
+		require.NoError(t, err)
+	}
+}
+
+func TestWriterRotate(t *testing.T) {
+	config := conf.NewConfig()
+	config.LogRotateSize = 200
+	l := newTestLogger(t, config)
+	w, err := l.Create("job", nil)
+	require.NoError(t, err)
+	writeLines(t, w, 0, 10)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	logchan, err := l.Tailf(ctx, "job", StreamOptions{})
+	require.NoError(t, err)
+	// the stream follows the log across the rotations
+	go func() {
+		for i := 10; i < 50; i += 5 {
+			time.Sleep(time.Millisecond * 20)
+			writeLines(t, w, i, i+5)
+		}
+	}()
+	var records []Output
+	for record := range logchan {
+		if records = append(records, record); len(records) == 50 {
+			break
+		}
+	}
+
+	require.Len(t, records, 50)
+	for i, record := range records {
+		assert.Equal(t, uint64(i+1), record.Seq)
+		assert.Equal(t, fmt.Sprintf("line %d\n", i), record.Data)
+	}
+	segments, err := l.segments("job")
+	require.NoError(t, err)
+	assert.NotEmpty(t, segments)
+	require.NoError(t, w.Close())
+}
+
+func TestWriterMaxSizeTruncate(t *testing.T) {
+	config := conf.NewConfig()
+	config.LogRotateSize = 200
+	config.LogMaxSize = 600
+	l := newTestLogger(t, config)
+	w, err := l.Create("job", nil)
+	require.NoError(t, err)
+	writeLines(t, w, 0, 50)
+	require.NoError(t, w.Close())
+
+	var size int64
+	files, err := filepath.Glob(l.Path("job") + "*")
+	require.NoError(t, err)
+	for _, file := range files {
+		stat, err := os.Stat(file)
+		require.NoError(t, err)
+		size += stat.Size()
+	}
+	assert.LessOrEqual(t, size, config.LogMaxSize)
+	// the oldest lines are removed
+	segments, err := l.segments("job")
+	require.NoError(t, err)
+	require.NotEmpty(t, segments)
+	records := readRecords(t, segments[0].path)
+	assert.Greater(t, records[0].Seq, uint64(1))
+}
+
+func TestWriterMaxSizeKill(t *testing.T) {
+	config := conf.NewConfig()
+	config.LogMaxSize = 200
+	config.LogLimitAction = conf.LogLimitKill
+	l := newTestLogger(t, config)
+	limited := make(chan struct{}, 10)
+	w, err := l.Create("job", func() { limited <- struct{}{} })
+	require.NoError(t, err)
+	writeLines(t, w, 0, 50)
+	require.NoError(t, w.Close())
+
+	select {
+	case <-limited:
+	case <-time.After(time.Second):
+		t.Fatal("the limit wasn't reached")
+	}
+	assert.Empty(t, limited)
+	stat, err := os.Stat(l.Path("job"))
+	require.NoError(t, err)
+	assert.LessOrEqual(t, stat.Size(), config.LogMaxSize)
+}
+
+func TestOpenOffset(t *testing.T) {
+	config := conf.NewConfig()
+	config.LogRotateSize = 200
+	l := newTestLogger(t, config)
+	createLog(t, l, "job", 10, 0)
+	reader, err := l.Open("job", 0)
+	require.NoError(t, err)
+	content, err := ioutil.ReadAll(reader)
+	require.NoError(t, err)
+	reader.Close()
+	require.Len(t, readRecords(t, l.Path("job")), 1)
+
+	// the offsets count across the rotated files
+	for _, offset := range []int{0, 100, 264, len(content) - 1, len(content)} {
+		reader, err := l.Open("job", int64(offset))
+		require.NoError(t, err)
+		rest, err := ioutil.ReadAll(reader)
+		require.NoError(t, err)
+		reader.Close()
+		assert.Equal(t, content[offset:], rest)
+	}
+}
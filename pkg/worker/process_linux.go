@@ -0,0 +1,35 @@
+package worker
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+)
+
+// processStart returns the start time of a process in clock ticks since the
+// boot, which together with the pid identifies a process even if the pid is
+// reused. See https://man7.org/linux/man-pages/man5/proc.5.html
+func processStart(pid int) (uint64, error) {
+	stat, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return 0, err
+	}
+	// the process name may contain spaces and parenthesis,
+	// the fields are read after the last parenthesis
+	fields := bytes.Fields(stat[bytes.LastIndexByte(stat, ')')+1:])
+	// the start time is the 22nd field, the state is the 3rd
+	if len(fields) < 20 {
+		return 0, fmt.Errorf("invalid stat of the process %d", pid)
+	}
+	if string(fields[0]) == "Z" {
+		return 0, fmt.Errorf("the process %d is a zombie", pid)
+	}
+	return strconv.ParseUint(string(fields[19]), 10, 64)
+}
+
+// isProcessAlive checks if the process with the given start time still running.
+func isProcessAlive(pid int, start uint64) bool {
+	current, err := processStart(pid)
+	return err == nil && current == start
+}
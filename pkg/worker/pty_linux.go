@@ -0,0 +1,108 @@
+package worker
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// WindowSize of a job pseudo-terminal.
+type WindowSize struct {
+	// Rows number of character rows
+	Rows uint16
+	// Cols number of character columns
+	Cols uint16
+}
+
+// winsize is the struct winsize used by the TIOCSWINSZ ioctl.
+type winsize struct {
+	rows   uint16
+	cols   uint16
+	xpixel uint16
+	ypixel uint16
+}
+
+// terminal is the pseudo-terminal of a job attempt, the slave
+// side is the controlling terminal of the job.
+type terminal struct {
+	// master side of the pseudo-terminal
+	master *os.File
+	// done is closed when the job output is copied to the log file
+	done chan struct{}
+}
+
+// openTerminal allocates a pseudo-terminal pair.
+// See https://man7.org/linux/man-pages/man4/pts.4.html
+func openTerminal() (master *os.File, slave *os.File, err error) {
+	master, err = os.OpenFile("/dev/ptmx", os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	var number uint32
+	err = control(master, func(fd int) error {
+		var unlock int32
+		if err := ioctl(fd, syscall.TIOCSPTLCK, unsafe.Pointer(&unlock)); err != nil {
+			return err
+		}
+		return ioctl(fd, syscall.TIOCGPTN, unsafe.Pointer(&number))
+	})
+	if err != nil {
+		master.Close()
+		return nil, nil, fmt.Errorf("fail to unlock the pseudo-terminal: %v", err)
+	}
+	slave, err = os.OpenFile(fmt.Sprintf("/dev/pts/%d", number), os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		master.Close()
+		return nil, nil, err
+	}
+	return master, slave, nil
+}
+
+// copyOutput copies the job output from the master side to the log file,
+// until the job and its children close the slave side. The master side is
+// closed once the job exits, closing it before hangs up the job.
+func (t *terminal) copyOutput(logfile io.Writer) {
+	defer close(t.done)
+	// reading the master side fails with EIO when the slave side is closed
+	io.Copy(logfile, t.master)
+}
+
+// resize sets the pseudo-terminal window size, which sends a SIGWINCH to the job.
+func (t *terminal) resize(size WindowSize) error {
+	ws := winsize{rows: size.Rows, cols: size.Cols}
+	return control(t.master, func(fd int) error {
+		return ioctl(fd, syscall.TIOCSWINSZ, unsafe.Pointer(&ws))
+	})
+}
+
+// control runs a function with the file descriptor, without
+// changing the file to blocking mode like the Fd method.
+func control(file *os.File, f func(fd int) error) error {
+	conn, err := file.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var ferr error
+	if err := conn.Control(func(fd uintptr) {
+		ferr = f(int(fd))
+	}); err != nil {
+		return err
+	}
+	return ferr
+}
+
+// attachTerminal makes the pseudo-terminal slave side the stdin, stdout,
+// stderr and the controlling terminal of the init process, which leads a
+// new session, and so a new process group as well.
+func (p *initProcess) attachTerminal(slave *os.File) {
+	p.cmd.Stdin = slave
+	p.cmd.Stdout = slave
+	p.cmd.Stderr = slave
+	p.cmd.SysProcAttr.Setpgid = false
+	p.cmd.SysProcAttr.Setsid = true
+	p.cmd.SysProcAttr.Setctty = true
+	// the controlling terminal is the child stdin
+	p.cmd.SysProcAttr.Ctty = 0
+}
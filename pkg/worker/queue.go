@@ -0,0 +1,74 @@
+package worker
+
+import "container/heap"
+
+// jobQueue is a priority queue of the Jobs waiting for a free slot, the
+// Jobs with higher priority first, and in queue order with the same priority.
+// It implements heap.Interface, see container/heap.
+type jobQueue []*Job
+
+func (q jobQueue) Len() int {
+	return len(q)
+}
+
+func (q jobQueue) Less(i, j int) bool {
+	return q[i].before(q[j])
+}
+
+func (q jobQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *jobQueue) Push(x interface{}) {
+	job := x.(*Job)
+	job.index = len(*q)
+	*q = append(*q, job)
+}
+
+func (q *jobQueue) Pop() interface{} {
+	old := *q
+	job := old[len(old)-1]
+	old[len(old)-1] = nil
+	job.index = -1
+	*q = old[:len(old)-1]
+	return job
+}
+
+// push adds a Job to the queue.
+func (q *jobQueue) push(job *Job) {
+	heap.Push(q, job)
+}
+
+// pop removes the next Job from the queue.
+func (q *jobQueue) pop() *Job {
+	return heap.Pop(q).(*Job)
+}
+
+// remove removes a Job from the queue.
+func (q *jobQueue) remove(job *Job) {
+	heap.Remove(q, job.index)
+}
+
+// position returns the position of a queued Job, starting from one.
+func (q jobQueue) position(job *Job) int {
+	position := 1
+	for _, other := range q {
+		if other.before(job) {
+			position++
+		}
+	}
+	return position
+}
+
+// before checks if the Job leaves the queue before the other Job.
+func (j *Job) before(other *Job) bool {
+	if j.Command.Priority != other.Command.Priority {
+		return j.Command.Priority > other.Command.Priority
+	}
+	if !j.Status.StartTime.Equal(other.Status.StartTime) {
+		return j.Status.StartTime.Before(other.Status.StartTime)
+	}
+	return j.ID < other.ID
+}
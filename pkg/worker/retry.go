@@ -0,0 +1,96 @@
+package worker
+
+import (
+	"errors"
+	"math/rand"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy of a failed job. The attempts run under the same Job, with
+// the output appended to the same log file.
+type RetryPolicy struct {
+	// MaxAttempts maximum number of attempts, including the first
+	// one, the job isn't retried if less than two
+	MaxAttempts int
+	// Backoff delay before the first retry, doubled on each retry
+	Backoff time.Duration
+	// MaxBackoff maximum delay between attempts, no maximum if zero
+	MaxBackoff time.Duration
+	// Jitter fraction of the delay, from 0 to 1, randomly
+	// subtracted from it, so the retries are spread out
+	Jitter float64
+	// ExitCodes retryable exit codes, any failure is retried if empty,
+	// including the termination by a signal, reported as -1
+	ExitCodes []int
+}
+
+// Attempt is the result of a finished Job attempt.
+type Attempt struct {
+	// Pid process identifier
+	Pid int
+	// ExitCode of the exited process, or -1 if the process
+	// was terminated by a signal
+	ExitCode int
+	// Exited reports whether the program has exited
+	Exited bool
+	// StartTime when the attempt was started
+	StartTime time.Time
+	// EndTime when the attempt was finished
+	EndTime time.Time
+	// Signal which terminated the process, zero if it exited
+	Signal syscall.Signal
+	// CoreDumped reports whether the terminated process dumped a core
+	CoreDumped bool
+	// Usage resources used by the attempt
+	Usage Usage
+}
+
+// validate checks the policy values.
+func (p RetryPolicy) validate() error {
+	if p.MaxAttempts < 0 {
+		return errors.New("the maximum number of attempts can't be negative")
+	}
+	if p.Backoff < 0 || p.MaxBackoff < 0 {
+		return errors.New("the retry backoff can't be negative")
+	}
+	if p.Jitter < 0 || p.Jitter > 1 {
+		return errors.New("the retry jitter must be between 0 and 1")
+	}
+	return nil
+}
+
+// retryable checks if a failed attempt, given its number
+// starting from one, can be retried.
+func (p RetryPolicy) retryable(attempt int, exitCode int) bool {
+	if exitCode == 0 || attempt >= p.MaxAttempts {
+		return false
+	}
+	if len(p.ExitCodes) == 0 {
+		return true
+	}
+	for _, code := range p.ExitCodes {
+		if code == exitCode {
+			return true
+		}
+	}
+	return false
+}
+
+// delay returns the backoff before the given retry, starting from one.
+func (p RetryPolicy) delay(retry int) time.Duration {
+	delay := p.Backoff
+	for i := 1; i < retry && delay < maxBackoff/2; i++ {
+		delay *= 2
+	}
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	if p.Jitter > 0 {
+		delay -= time.Duration(rand.Float64() * p.Jitter * float64(delay))
+	}
+	return delay
+}
+
+// maxBackoff upper bound of the exponential backoff, to avoid overflows.
+const maxBackoff = time.Duration(1<<63 - 1)
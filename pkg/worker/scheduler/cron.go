@@ -0,0 +1,221 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSearchYears limits the search of the next activation, so an
+// expression which never matches, e.g. 30 of February, ends.
+const maxSearchYears = 5
+
+// field bounds of a cron expression.
+type bounds struct {
+	name     string
+	min, max uint
+	names    map[string]uint
+}
+
+var (
+	seconds = bounds{name: "second", min: 0, max: 59}
+	minutes = bounds{name: "minute", min: 0, max: 59}
+	hours   = bounds{name: "hour", min: 0, max: 23}
+	days    = bounds{name: "day of month", min: 1, max: 31}
+	months  = bounds{name: "month", min: 1, max: 12, names: map[string]uint{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	weekdays = bounds{name: "day of week", min: 0, max: 7, names: map[string]uint{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// descriptors are shortcuts for common expressions.
+var descriptors = map[string]string{
+	"@yearly":   "0 0 0 1 1 *",
+	"@annually": "0 0 0 1 1 *",
+	"@monthly":  "0 0 0 1 * *",
+	"@weekly":   "0 0 0 * * 0",
+	"@daily":    "0 0 0 * * *",
+	"@midnight": "0 0 0 * * *",
+	"@hourly":   "0 0 * * * *",
+}
+
+// Cron is a parsed cron expression, each field is a bit set of the
+// matching values.
+type Cron struct {
+	second, minute, hour, day, month, weekday uint64
+	// anyDay and anyWeekday report whether the day fields are
+	// unrestricted, when both are restricted, a day matching
+	// any of them is activated
+	anyDay, anyWeekday bool
+}
+
+// ParseCron parses a cron expression with five fields, minute, hour, day
+// of month, month and day of week, or six fields with the leading second.
+// Each field accepts *, values, ranges, lists and steps, e.g. 1-5,10,*/15.
+// Months and days of week accept the three letter names, e.g. JAN, MON.
+// The descriptors @yearly, @annually, @monthly, @weekly, @daily, @midnight
+// and @hourly are also accepted.
+func ParseCron(expr string) (*Cron, error) {
+	expr = strings.TrimSpace(expr)
+	if desc, ok := descriptors[strings.ToLower(expr)]; ok {
+		expr = desc
+	}
+	fields := strings.Fields(expr)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("invalid cron expression %q, expected 5 or 6 fields", expr)
+	}
+	var cron Cron
+	var err error
+	parsers := []struct {
+		bits   *uint64
+		bounds bounds
+	}{
+		{&cron.second, seconds},
+		{&cron.minute, minutes},
+		{&cron.hour, hours},
+		{&cron.day, days},
+		{&cron.month, months},
+		{&cron.weekday, weekdays},
+	}
+	for i, p := range parsers {
+		if *p.bits, err = parseField(fields[i], p.bounds); err != nil {
+			return nil, err
+		}
+	}
+	// 7 is also sunday
+	if cron.weekday&(1<<7) != 0 {
+		cron.weekday |= 1
+	}
+	cron.anyDay = isAny(fields[3])
+	cron.anyWeekday = isAny(fields[5])
+	return &cron, nil
+}
+
+// isAny checks if the field is unrestricted.
+func isAny(field string) bool {
+	return field == "*" || field == "?"
+}
+
+// parseField parses a comma separated list of ranges.
+func parseField(field string, b bounds) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		r, err := parseRange(part, b)
+		if err != nil {
+			return 0, err
+		}
+		bits |= r
+	}
+	return bits, nil
+}
+
+// parseRange parses *, a value or a range with an optional step.
+func parseRange(expr string, b bounds) (uint64, error) {
+	rangeExpr, step := expr, uint(1)
+	if i := strings.Index(expr, "/"); i >= 0 {
+		value, err := strconv.ParseUint(expr[i+1:], 10, 8)
+		if err != nil || value == 0 {
+			return 0, fmt.Errorf("invalid %s step %q", b.name, expr)
+		}
+		rangeExpr, step = expr[:i], uint(value)
+	}
+	var start, end uint
+	switch {
+	case isAny(rangeExpr):
+		start, end = b.min, b.max
+	case strings.Contains(rangeExpr, "-"):
+		parts := strings.SplitN(rangeExpr, "-", 2)
+		var err error
+		if start, err = parseValue(parts[0], b); err != nil {
+			return 0, err
+		}
+		if end, err = parseValue(parts[1], b); err != nil {
+			return 0, err
+		}
+	default:
+		value, err := parseValue(rangeExpr, b)
+		if err != nil {
+			return 0, err
+		}
+		start, end = value, value
+		// a single value with step, e.g. 5/15, ends at the max
+		if step > 1 {
+			end = b.max
+		}
+	}
+	if start > end {
+		return 0, fmt.Errorf("invalid %s range %q", b.name, expr)
+	}
+	var bits uint64
+	for value := start; value <= end; value += step {
+		bits |= 1 << value
+	}
+	return bits, nil
+}
+
+// parseValue parses a number or a name within the bounds.
+func parseValue(expr string, b bounds) (uint, error) {
+	if value, ok := b.names[strings.ToLower(expr)]; ok {
+		return value, nil
+	}
+	value, err := strconv.ParseUint(expr, 10, 8)
+	if err != nil || uint(value) < b.min || uint(value) > b.max {
+		return 0, fmt.Errorf("invalid %s %q", b.name, expr)
+	}
+	return uint(value), nil
+}
+
+// Next returns the first activation time after the given time, in the
+// time location of the given time. The zero time is returned if there
+// is no activation in the next years.
+func (c *Cron) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Second).Add(time.Second)
+	limit := t.AddDate(maxSearchYears, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case !has(c.month, uint(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !c.matchDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case !has(c.hour, uint(t.Hour())):
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case !has(c.minute, uint(t.Minute())):
+			t = t.Truncate(time.Minute).Add(time.Minute)
+		case !has(c.second, uint(t.Second())):
+			t = t.Add(time.Second)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// matchDay checks the day of month and the day of week.
+func (c *Cron) matchDay(t time.Time) bool {
+	day := has(c.day, uint(t.Day()))
+	weekday := has(c.weekday, uint(t.Weekday()))
+	switch {
+	case c.anyDay && c.anyWeekday:
+		return true
+	case c.anyDay:
+		return weekday
+	case c.anyWeekday:
+		return day
+	default:
+		return day || weekday
+	}
+}
+
+// has checks if the value is in the bit set.
+func has(bits uint64, value uint) bool {
+	return bits&(1<<value) != 0
+}
@@ -0,0 +1,55 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCronInvalid(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "* * * foo *"} {
+		_, err := ParseCron(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	base := time.Date(2021, time.May, 2, 17, 54, 29, 500, time.UTC)
+	cases := map[string]time.Time{
+		"* * * * * *":          time.Date(2021, time.May, 2, 17, 54, 30, 0, time.UTC),
+		"*/15 * * * * *":       time.Date(2021, time.May, 2, 17, 54, 30, 0, time.UTC),
+		"* * * * *":            time.Date(2021, time.May, 2, 17, 55, 0, 0, time.UTC),
+		"0 9-17/4 * * MON-FRI": time.Date(2021, time.May, 3, 9, 0, 0, 0, time.UTC),
+		"30 2 1 jan *":         time.Date(2022, time.January, 1, 2, 30, 0, 0, time.UTC),
+		"0 0 13 * 5":           time.Date(2021, time.May, 7, 0, 0, 0, 0, time.UTC),
+		"0 0 * * 7":            time.Date(2021, time.May, 9, 0, 0, 0, 0, time.UTC),
+		"@hourly":              time.Date(2021, time.May, 2, 18, 0, 0, 0, time.UTC),
+		"0 0 29 2 *":           time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+	}
+	for expr, expected := range cases {
+		cron, err := ParseCron(expr)
+		require.NoError(t, err, expr)
+		assert.Equal(t, expected, cron.Next(base), expr)
+	}
+}
+
+func TestCronNextNever(t *testing.T) {
+	cron, err := ParseCron("0 0 30 2 *")
+	require.NoError(t, err)
+
+	assert.True(t, cron.Next(time.Now()).IsZero())
+}
+
+func TestCronNextTimeZone(t *testing.T) {
+	loc, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Skip("time zone database is not available")
+	}
+	cron, err := ParseCron("0 0 9 * * *")
+	require.NoError(t, err)
+
+	next := cron.Next(time.Date(2021, time.May, 2, 12, 0, 0, 0, time.UTC).In(loc))
+	assert.Equal(t, time.Date(2021, time.May, 3, 12, 0, 0, 0, time.UTC), next.UTC())
+}
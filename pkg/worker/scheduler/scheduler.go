@@ -0,0 +1,296 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	logger "log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/renatoaguimaraes/job-scheduler/pkg/worker"
+)
+
+// ConcurrencyPolicy defines what happens when a schedule activation
+// overlaps with the previous run still running.
+type ConcurrencyPolicy int
+
+const (
+	// ConcurrencyAllow starts a new Job anyway.
+	ConcurrencyAllow ConcurrencyPolicy = iota
+	// ConcurrencyForbid skips the activation.
+	ConcurrencyForbid
+	// ConcurrencyReplace stops the previous Job and starts a new one
+	// once the previous Job is finished.
+	ConcurrencyReplace
+)
+
+const (
+	// replacePollInterval interval to check if the replaced Job is finished
+	replacePollInterval = time.Millisecond * 50
+	// defaultReplaceTimeout maximum time to wait for the replaced Job to
+	// finish after the stop, before it's killed
+	defaultReplaceTimeout = time.Minute
+)
+
+// Spec of a schedule.
+type Spec struct {
+	// Cron expression, see ParseCron
+	Cron string
+	// TimeZone IANA name of the cron time zone, e.g. America/Sao_Paulo,
+	// UTC is used if empty
+	TimeZone string
+	// Command started on each activation
+	Command worker.Command
+	// Policy of overlapping runs
+	Policy ConcurrencyPolicy
+}
+
+// Schedule is a snapshot of a created schedule.
+type Schedule struct {
+	// ID schedule identifier
+	ID string
+	// Spec of the schedule
+	Spec Spec
+	// JobIDs of the Jobs started by the schedule, in start order
+	JobIDs []string
+	// NextRun time of the next activation
+	NextRun time.Time
+}
+
+// Scheduler defines the operations to manage recurring Jobs.
+type Scheduler interface {
+	// Create schedules a Command.
+	//    - spec: cron expression, command and concurrency policy
+	// It returns the schedule ID and the execution error encountered.
+	Create(spec Spec) (scheduleID string, err error)
+	// Delete removes a schedule, the Jobs already started keep running.
+	//    - ID: schedule identifier
+	// It returns the execution error encountered.
+	Delete(scheduleID string) (err error)
+	// List returns the schedules ordered by ID.
+	List() (schedules []Schedule)
+	// Close removes all schedules.
+	Close()
+}
+
+// NewScheduler creates a new Scheduler which starts the Jobs on the given Worker.
+func NewScheduler(w worker.Worker) Scheduler {
+	return &scheduler{
+		worker:         w,
+		schedules:      make(map[string]*schedule),
+		replaceTimeout: defaultReplaceTimeout,
+	}
+}
+
+// scheduler implementation.
+type scheduler struct {
+	// worker starts the jobs
+	worker worker.Worker
+	// schedules by identifier
+	schedules map[string]*schedule
+	// replaceTimeout maximum time to wait for the replaced Job to finish
+	// after the stop, before it's killed
+	replaceTimeout time.Duration
+	// mtx to control schedules concurrent access
+	mtx sync.Mutex
+}
+
+// schedule is a created schedule.
+type schedule struct {
+	id       string
+	spec     Spec
+	cron     *Cron
+	location *time.Location
+	jobIDs   []string
+	next     time.Time
+	// stop is closed when the schedule is deleted
+	stop chan struct{}
+}
+
+// deleted checks if the schedule was deleted.
+func (sc *schedule) deleted() bool {
+	select {
+	case <-sc.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// Create parses the cron expression and launches a goroutine which
+// starts the Command on each activation.
+func (s *scheduler) Create(spec Spec) (string, error) {
+	cron, err := ParseCron(spec.Cron)
+	if err != nil {
+		return "", err
+	}
+	location := time.UTC
+	if spec.TimeZone != "" {
+		if location, err = time.LoadLocation(spec.TimeZone); err != nil {
+			return "", fmt.Errorf("invalid time zone %q: %v", spec.TimeZone, err)
+		}
+	}
+	if spec.Command.Name == "" {
+		return "", errors.New("you must pass a program name")
+	}
+	sc := &schedule{
+		id:       uuid.NewString(),
+		spec:     spec,
+		cron:     cron,
+		location: location,
+		stop:     make(chan struct{}),
+	}
+	s.mtx.Lock()
+	s.schedules[sc.id] = sc
+	s.mtx.Unlock()
+	go s.run(sc)
+	return sc.id, nil
+}
+
+// Delete stops the schedule activations.
+func (s *scheduler) Delete(scheduleID string) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	sc, ok := s.schedules[scheduleID]
+	if !ok {
+		return fmt.Errorf("Schedule %v not found", scheduleID)
+	}
+	close(sc.stop)
+	delete(s.schedules, scheduleID)
+	return nil
+}
+
+// List returns a snapshot of the schedules.
+func (s *scheduler) List() []Schedule {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	schedules := make([]Schedule, 0, len(s.schedules))
+	for _, sc := range s.schedules {
+		schedules = append(schedules, Schedule{
+			ID:      sc.id,
+			Spec:    sc.spec,
+			JobIDs:  append([]string(nil), sc.jobIDs...),
+			NextRun: sc.next,
+		})
+	}
+	sort.Slice(schedules, func(i, j int) bool {
+		return schedules[i].ID < schedules[j].ID
+	})
+	return schedules
+}
+
+// Close stops all schedules.
+func (s *scheduler) Close() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for id, sc := range s.schedules {
+		close(sc.stop)
+		delete(s.schedules, id)
+	}
+}
+
+// run waits for each activation until the schedule is deleted.
+func (s *scheduler) run(sc *schedule) {
+	for {
+		next := sc.cron.Next(time.Now().In(sc.location))
+		s.mtx.Lock()
+		sc.next = next
+		s.mtx.Unlock()
+		if next.IsZero() {
+			logger.Printf("Schedule %v has no next activation", sc.id)
+			return
+		}
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-timer.C:
+			s.activate(sc)
+		case <-sc.stop:
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// activate starts the Command applying the concurrency policy. The worker is
+// called without holding the lock, so the schedules are available meanwhile,
+// the activations of a schedule are sequential.
+func (s *scheduler) activate(sc *schedule) {
+	if sc.deleted() {
+		return
+	}
+	s.mtx.Lock()
+	previous := sc.last()
+	s.mtx.Unlock()
+	if previous != "" && s.running(previous) {
+		switch sc.spec.Policy {
+		case ConcurrencyForbid:
+			logger.Printf("Schedule %v skipped, the job %v is still running", sc.id, previous)
+			return
+		case ConcurrencyReplace:
+			if !s.replace(sc, previous) || sc.deleted() {
+				return
+			}
+		}
+	}
+	jobID, err := s.worker.Start(sc.spec.Command)
+	if err != nil {
+		logger.Printf("Schedule %v fails to start the job, %v", sc.id, err)
+		return
+	}
+	s.mtx.Lock()
+	sc.jobIDs = append(sc.jobIDs, jobID)
+	s.mtx.Unlock()
+}
+
+// replace stops the previous Job, and waits for it to finish, so the runs
+// don't overlap. The Job is killed if it isn't finished after the replace
+// timeout. It returns false if the Job is still running, or the schedule
+// was deleted meanwhile.
+func (s *scheduler) replace(sc *schedule, previous string) bool {
+	if err := s.worker.Stop(previous, worker.StopOptions{}); err != nil {
+		logger.Printf("Schedule %v fails to stop the job %v, %v", sc.id, previous, err)
+	}
+	ticker := time.NewTicker(replacePollInterval)
+	defer ticker.Stop()
+	deadline := time.Now().Add(s.replaceTimeout)
+	forced := false
+	for {
+		status, err := s.worker.Query(previous)
+		if err != nil || !status.IsRunning() {
+			return true
+		}
+		if time.Now().After(deadline) {
+			if forced {
+				logger.Printf("Schedule %v skipped, the job %v is still running after killed", sc.id, previous)
+				return false
+			}
+			if err := s.worker.Stop(previous, worker.StopOptions{Force: true}); err != nil {
+				logger.Printf("Schedule %v fails to kill the job %v, %v", sc.id, previous, err)
+			}
+			forced = true
+			deadline = time.Now().Add(s.replaceTimeout)
+		}
+		select {
+		case <-ticker.C:
+		case <-sc.stop:
+			return false
+		}
+	}
+}
+
+// last returns the last Job started by the schedule, empty if none.
+// It must be called holding the lock.
+func (sc *schedule) last() string {
+	if len(sc.jobIDs) == 0 {
+		return ""
+	}
+	return sc.jobIDs[len(sc.jobIDs)-1]
+}
+
+// running checks if a Job started by the schedule still running.
+func (s *scheduler) running(jobID string) bool {
+	status, err := s.worker.Query(jobID)
+	return err == nil && status.IsRunning()
+}
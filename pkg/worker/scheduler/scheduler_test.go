@@ -0,0 +1,179 @@
+package scheduler
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/renatoaguimaraes/job-scheduler/pkg/worker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeWorker keeps the jobs running until stopped, the stopped jobs
+// finish after the grace period, or at once if forced.
+type fakeWorker struct {
+	worker.Worker
+	mtx     sync.Mutex
+	started []string
+	stopped []string
+	forced  []string
+	// ends when the stopped jobs finish
+	ends  map[string]time.Time
+	grace time.Duration
+	// overlaps jobs started while another job was running
+	overlaps int
+	// delay of the job starts
+	delay time.Duration
+}
+
+func (w *fakeWorker) Start(command worker.Command) (string, error) {
+	time.Sleep(w.delay)
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	for _, started := range w.started {
+		if w.running(started) {
+			w.overlaps++
+		}
+	}
+	jobID := fmt.Sprintf("job-%d", len(w.started))
+	w.started = append(w.started, jobID)
+	return jobID, nil
+}
+
+func (w *fakeWorker) Stop(jobID string, options worker.StopOptions) error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	if w.ends == nil {
+		w.ends = make(map[string]time.Time)
+	}
+	end := time.Now().Add(w.grace)
+	if options.Force {
+		w.forced = append(w.forced, jobID)
+		end = time.Now()
+	} else {
+		w.stopped = append(w.stopped, jobID)
+	}
+	if previous, ok := w.ends[jobID]; !ok || end.Before(previous) {
+		w.ends[jobID] = end
+	}
+	return nil
+}
+
+func (w *fakeWorker) Query(jobID string) (worker.Status, error) {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	if !w.running(jobID) {
+		return worker.Status{State: worker.StateKilled, ExitCode: -1}, nil
+	}
+	return worker.Status{State: worker.StateRunning}, nil
+}
+
+// running checks if a job is running. It must be called holding the lock.
+func (w *fakeWorker) running(jobID string) bool {
+	end, ok := w.ends[jobID]
+	return !ok || time.Now().Before(end)
+}
+
+func runSchedule(t *testing.T, w *fakeWorker, policy ConcurrencyPolicy, replaceTimeout time.Duration) Schedule {
+	s := NewScheduler(w)
+	s.(*scheduler).replaceTimeout = replaceTimeout
+	defer s.Close()
+	_, err := s.Create(Spec{Cron: "* * * * * *", Command: worker.Command{Name: "sleep"}, Policy: policy})
+	require.NoError(t, err)
+
+	time.Sleep(time.Millisecond * 3100)
+
+	schedules := s.List()
+	require.Len(t, schedules, 1)
+	return schedules[0]
+}
+
+func TestScheduleAllow(t *testing.T) {
+	w := &fakeWorker{}
+	sc := runSchedule(t, w, ConcurrencyAllow, defaultReplaceTimeout)
+
+	assert.GreaterOrEqual(t, len(sc.JobIDs), 2)
+	assert.Equal(t, w.started, sc.JobIDs)
+	assert.Empty(t, w.stopped)
+	assert.True(t, sc.NextRun.After(time.Now()))
+}
+
+func TestScheduleForbid(t *testing.T) {
+	w := &fakeWorker{}
+	sc := runSchedule(t, w, ConcurrencyForbid, defaultReplaceTimeout)
+
+	assert.Equal(t, []string{"job-0"}, sc.JobIDs)
+	assert.Empty(t, w.stopped)
+}
+
+func TestScheduleReplace(t *testing.T) {
+	w := &fakeWorker{}
+	sc := runSchedule(t, w, ConcurrencyReplace, defaultReplaceTimeout)
+
+	// all jobs but the last one were replaced
+	require.GreaterOrEqual(t, len(sc.JobIDs), 2)
+	assert.Equal(t, sc.JobIDs[:len(sc.JobIDs)-1], w.stopped)
+}
+
+func TestScheduleReplaceWaits(t *testing.T) {
+	w := &fakeWorker{grace: time.Millisecond * 300}
+	sc := runSchedule(t, w, ConcurrencyReplace, defaultReplaceTimeout)
+
+	// the next job starts once the stopped job is finished
+	require.GreaterOrEqual(t, len(sc.JobIDs), 2)
+	assert.Zero(t, w.overlaps)
+	assert.Empty(t, w.forced)
+}
+
+func TestScheduleReplaceKills(t *testing.T) {
+	w := &fakeWorker{grace: time.Hour}
+	sc := runSchedule(t, w, ConcurrencyReplace, time.Millisecond*200)
+
+	// the stopped job is killed if it doesn't finish in time
+	require.GreaterOrEqual(t, len(sc.JobIDs), 2)
+	assert.Zero(t, w.overlaps)
+	assert.Equal(t, sc.JobIDs[:len(sc.JobIDs)-1], w.forced)
+}
+
+func TestScheduleListWhileStarting(t *testing.T) {
+	w := &fakeWorker{delay: time.Millisecond * 500}
+	s := NewScheduler(w)
+	defer s.Close()
+	_, err := s.Create(Spec{Cron: "* * * * * *", Command: worker.Command{Name: "sleep"}})
+	require.NoError(t, err)
+
+	// the schedules are listed while the jobs are started
+	var slowest time.Duration
+	for end := time.Now().Add(time.Millisecond * 2100); time.Now().Before(end); {
+		start := time.Now()
+		s.List()
+		if elapsed := time.Since(start); elapsed > slowest {
+			slowest = elapsed
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+	assert.Less(t, int64(slowest), int64(time.Millisecond*100))
+	require.Len(t, s.List(), 1)
+	assert.NotEmpty(t, s.List()[0].JobIDs)
+}
+
+func TestScheduleInvalid(t *testing.T) {
+	s := NewScheduler(&fakeWorker{})
+
+	_, err := s.Create(Spec{Cron: "invalid", Command: worker.Command{Name: "ls"}})
+	assert.Error(t, err)
+	_, err = s.Create(Spec{Cron: "* * * * *", TimeZone: "Invalid/Zone", Command: worker.Command{Name: "ls"}})
+	assert.Error(t, err)
+}
+
+func TestScheduleDelete(t *testing.T) {
+	s := NewScheduler(&fakeWorker{})
+	id, err := s.Create(Spec{Cron: "@daily", Command: worker.Command{Name: "ls"}})
+	require.NoError(t, err)
+
+	assert.NoError(t, s.Delete(id))
+	assert.Empty(t, s.List())
+	assert.Error(t, s.Delete(id))
+}
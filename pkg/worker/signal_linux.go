@@ -0,0 +1,122 @@
+package worker
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// signals by name
+var signals = map[string]syscall.Signal{
+	"SIGHUP":    syscall.SIGHUP,
+	"SIGINT":    syscall.SIGINT,
+	"SIGQUIT":   syscall.SIGQUIT,
+	"SIGILL":    syscall.SIGILL,
+	"SIGTRAP":   syscall.SIGTRAP,
+	"SIGABRT":   syscall.SIGABRT,
+	"SIGBUS":    syscall.SIGBUS,
+	"SIGFPE":    syscall.SIGFPE,
+	"SIGKILL":   syscall.SIGKILL,
+	"SIGUSR1":   syscall.SIGUSR1,
+	"SIGSEGV":   syscall.SIGSEGV,
+	"SIGUSR2":   syscall.SIGUSR2,
+	"SIGPIPE":   syscall.SIGPIPE,
+	"SIGALRM":   syscall.SIGALRM,
+	"SIGTERM":   syscall.SIGTERM,
+	"SIGSTKFLT": syscall.SIGSTKFLT,
+	"SIGCHLD":   syscall.SIGCHLD,
+	"SIGCONT":   syscall.SIGCONT,
+	"SIGSTOP":   syscall.SIGSTOP,
+	"SIGTSTP":   syscall.SIGTSTP,
+	"SIGTTIN":   syscall.SIGTTIN,
+	"SIGTTOU":   syscall.SIGTTOU,
+	"SIGURG":    syscall.SIGURG,
+	"SIGXCPU":   syscall.SIGXCPU,
+	"SIGXFSZ":   syscall.SIGXFSZ,
+	"SIGVTALRM": syscall.SIGVTALRM,
+	"SIGPROF":   syscall.SIGPROF,
+	"SIGWINCH":  syscall.SIGWINCH,
+	"SIGIO":     syscall.SIGIO,
+	"SIGPWR":    syscall.SIGPWR,
+	"SIGSYS":    syscall.SIGSYS,
+}
+
+// allowedSignals which can be sent to a Job, the job is paused and resumed
+// with Pause and Resume instead of SIGSTOP and SIGCONT.
+var allowedSignals = map[syscall.Signal]bool{
+	syscall.SIGHUP:   true,
+	syscall.SIGINT:   true,
+	syscall.SIGQUIT:  true,
+	syscall.SIGUSR1:  true,
+	syscall.SIGUSR2:  true,
+	syscall.SIGALRM:  true,
+	syscall.SIGTERM:  true,
+	syscall.SIGKILL:  true,
+	syscall.SIGWINCH: true,
+}
+
+// ParseSignal returns the signal of a name, e.g. SIGHUP or hup, the SIG
+// prefix is optional and the case is ignored.
+func ParseSignal(name string) (syscall.Signal, error) {
+	upper := strings.ToUpper(name)
+	if !strings.HasPrefix(upper, "SIG") {
+		upper = "SIG" + upper
+	}
+	sig, ok := signals[upper]
+	if !ok {
+		return 0, fmt.Errorf("unknown signal %q", name)
+	}
+	return sig, nil
+}
+
+// SignalName returns the name of a signal, e.g. SIGKILL, or an empty string
+// if it's zero.
+func SignalName(sig syscall.Signal) string {
+	if sig == 0 {
+		return ""
+	}
+	for name, s := range signals {
+		if s == sig {
+			return name
+		}
+	}
+	return sig.String()
+}
+
+// exitSignal returns the signal which terminated a process, if any, and
+// whether it dumped a core.
+func exitSignal(state *os.ProcessState) (syscall.Signal, bool) {
+	status, ok := state.Sys().(syscall.WaitStatus)
+	if !ok {
+		return 0, false
+	}
+	return waitSignal(status)
+}
+
+// waitSignal returns the signal of a wait status, if any, and whether
+// the process dumped a core.
+func waitSignal(status syscall.WaitStatus) (syscall.Signal, bool) {
+	if !status.Signaled() {
+		return 0, false
+	}
+	return status.Signal(), status.CoreDump()
+}
+
+// processUsage returns the resources used by a process and its children
+// which it waited for.
+func processUsage(state *os.ProcessState) Usage {
+	rusage, ok := state.SysUsage().(*syscall.Rusage)
+	if !ok || rusage == nil {
+		return Usage{}
+	}
+	return Usage{
+		UserTime:   time.Duration(rusage.Utime.Nano()),
+		SystemTime: time.Duration(rusage.Stime.Nano()),
+		// kilobytes on Linux
+		MaxRSS:   rusage.Maxrss * 1024,
+		InBlock:  rusage.Inblock,
+		OutBlock: rusage.Oublock,
+	}
+}
@@ -0,0 +1,120 @@
+package worker
+
+import "fmt"
+
+// State of a Job in its lifecycle.
+type State int
+
+const (
+	// stateUnknown the Jobs stored before the states were introduced have
+	// no state, it's derived from their status when loaded, see legacyState.
+	stateUnknown State = iota
+	// StatePending the Job is queued, or waiting for the next attempt.
+	StatePending
+	// StateRunning the Job process is running.
+	StateRunning
+	// StateStopping the Job was signaled by the worker, and its process
+	// is still running.
+	StateStopping
+	// StateSucceeded the Job exited with code 0.
+	StateSucceeded
+	// StateFailed the Job exited with a non-zero exit code, or couldn't
+	// be started.
+	StateFailed
+	// StateKilled the Job was stopped by the worker, or terminated by a signal.
+	StateKilled
+	// StateTimedOut the Job was stopped because its timeout or deadline has passed.
+	StateTimedOut
+	// StateLost the Job outcome is unknown, because the worker was restarted
+	// while the Job was running.
+	StateLost
+	// StatePaused the Job processes are suspended until resumed.
+	StatePaused
+)
+
+// String returns the state name.
+func (s State) String() string {
+	switch s {
+	case StatePending:
+		return "pending"
+	case StateRunning:
+		return "running"
+	case StateStopping:
+		return "stopping"
+	case StateSucceeded:
+		return "succeeded"
+	case StateFailed:
+		return "failed"
+	case StateKilled:
+		return "killed"
+	case StateTimedOut:
+		return "timed_out"
+	case StateLost:
+		return "lost"
+	case StatePaused:
+		return "paused"
+	default:
+		return "unknown"
+	}
+}
+
+// finished checks if the state is final.
+func (s State) finished() bool {
+	return s != StatePending && s != StateRunning && s != StateStopping && s != StatePaused
+}
+
+// transitions valid next states of each state, the final states have none.
+var transitions = map[State][]State{
+	StatePending:  {StateRunning, StateFailed, StateKilled, StateTimedOut, StateLost},
+	StateRunning:  {StatePending, StatePaused, StateStopping, StateSucceeded, StateFailed, StateKilled, StateTimedOut, StateLost},
+	StatePaused:   {StateRunning, StatePending, StateStopping, StateSucceeded, StateFailed, StateKilled, StateLost},
+	StateStopping: {StateKilled, StateTimedOut, StateLost},
+}
+
+// transition moves the Job to the next state. It returns an error, and the
+// state is kept, if the transition isn't valid. It must be called holding
+// the lock.
+func (j *Job) transition(to State) error {
+	from := j.Status.State
+	for _, next := range transitions[from] {
+		if next == to {
+			j.Status.State = to
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid transition of the job %v from %v to %v", j.ID, from, to)
+}
+
+// finalState returns the state of a finished Job given its status.
+func finalState(s Status) State {
+	switch {
+	case s.Lost:
+		return StateLost
+	case s.TimedOut:
+		return StateTimedOut
+	case s.Termination != TerminationNone:
+		return StateKilled
+	case s.Exited && s.ExitCode == 0:
+		return StateSucceeded
+	case s.Signal != 0:
+		return StateKilled
+	default:
+		return StateFailed
+	}
+}
+
+// legacyState derives the state of a Job stored without state. The Job was
+// running if the exit code and the exited flag weren't set yet, and it was
+// waiting for the next attempt if its current attempt was finished.
+func legacyState(s Status) State {
+	switch {
+	case s.Queued:
+		return StatePending
+	case s.ExitCode != 0 || s.Exited || s.Lost:
+		return finalState(s)
+	case s.Attempt > 0 && s.Attempt == len(s.Attempts):
+		return StatePending
+	default:
+		return StateRunning
+	}
+}
@@ -0,0 +1,67 @@
+package worker
+
+import (
+	"sync"
+	"time"
+)
+
+// JobRecord is the persistent state of a Job.
+type JobRecord struct {
+	// ID job identifier
+	ID string
+	// Command requested
+	Command Command
+	// Status of the process
+	Status Status
+	// CreateTime when the Job was requested
+	CreateTime time.Time
+	// ProcessStart process start time in clock ticks since boot,
+	// used to recognize the process after a restart
+	ProcessStart uint64
+}
+
+// JobStore persists the Job records, so the Jobs survive Worker restarts.
+type JobStore interface {
+	// Save stores the latest record of a Job.
+	Save(record JobRecord) error
+	// Load returns the latest record of each stored Job.
+	Load() ([]JobRecord, error)
+	// Close releases the store resources.
+	Close() error
+}
+
+// NewMemoryStore returns a JobStore which keeps the
+// records in memory, so they don't survive restarts.
+func NewMemoryStore() JobStore {
+	return &memoryStore{records: make(map[string]JobRecord)}
+}
+
+// memoryStore in memory JobStore implementation.
+type memoryStore struct {
+	records map[string]JobRecord
+	mtx     sync.Mutex
+}
+
+// Save stores the record in memory.
+func (s *memoryStore) Save(record JobRecord) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.records[record.ID] = record
+	return nil
+}
+
+// Load returns the records stored in memory.
+func (s *memoryStore) Load() ([]JobRecord, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	var records []JobRecord
+	for _, record := range s.records {
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// Close does nothing.
+func (s *memoryStore) Close() error {
+	return nil
+}
@@ -4,12 +4,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	logger "log"
+	"os"
 	"os/exec"
+	"strings"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/renatoaguimaraes/job-scheduler/pkg/worker/cgroup"
 	"github.com/renatoaguimaraes/job-scheduler/pkg/worker/conf"
 	"github.com/renatoaguimaraes/job-scheduler/pkg/worker/log"
 )
@@ -20,25 +25,134 @@ type Command struct {
 	Name string
 	// Args program arguments
 	Args []string
+	// Limits of CPU, memory and IO resources, the job
+	// runs in a dedicated control group if any is set
+	Limits cgroup.Limits
+	// Isolation of the job from the host
+	Isolation Isolation
+	// Timeout maximum run duration, the job is stopped when it's
+	// exceeded, no timeout if zero
+	Timeout time.Duration
+	// Deadline absolute time when the job is stopped, if not zero,
+	// the earliest of timeout and deadline is applied
+	Deadline time.Time
+	// Retry policy of the failed attempts
+	Retry RetryPolicy
+	// Priority of the job in the queue, the jobs with higher
+	// priority are started first when there is a free slot
+	Priority int
+	// Env environment variables, in the form key=value, merged
+	// with the worker variables allowed by the configuration
+	Env []string
+	// Dir working directory, the worker working directory if empty
+	Dir string
+	// User the job runs as, a user name or uid optionally followed
+	// by a group name or gid, e.g. nobody or 1000:1000, the worker
+	// user if empty
+	User string
+	// Interactive keeps the job stdin open, so the input can be
+	// written by Attach, otherwise the job has no stdin
+	Interactive bool
+	// TTY runs the job with a pseudo-terminal as its controlling
+	// terminal, the terminal output is copied to the log file
+	TTY bool
 }
 
+// deadline returns the effective deadline of a command started at the
+// given time, the zero time if there is none.
+func (c Command) deadline(start time.Time) time.Time {
+	deadline := c.Deadline
+	if c.Timeout > 0 {
+		if timeout := start.Add(c.Timeout); deadline.IsZero() || timeout.Before(deadline) {
+			deadline = timeout
+		}
+	}
+	return deadline
+}
+
+// Isolation of a job from the host.
+type Isolation int
+
+const (
+	// IsolationDefault uses the isolation set in the worker configuration.
+	IsolationDefault Isolation = iota
+	// IsolationNone shares the worker namespaces with the job.
+	IsolationNone
+	// IsolationNamespaces runs the job in new PID, mount, network, UTS and IPC
+	// namespaces, with /proc remounted inside the new PID namespace.
+	IsolationNamespaces
+)
+
 // Job represents an arbitrary Linux process schedule by the Worker.
 type Job struct {
 	// ID job identifier
 	ID string
-	// Command pipeline
+	// Command requested
+	Command Command
+	// Command pipeline, nil if the Job was loaded from the store
 	Cmd *exec.Cmd
+	// init process of the current attempt, nil if the Job was loaded from the store
+	init *initProcess
 	// Status of the process.
 	Status *Status
+	// CreateTime when the Job was requested, unlike the start time it
+	// doesn't change when a queued Job is started
+	CreateTime time.Time
+	// Cgroup control group of the job, nil if the job has no limits
+	Cgroup *cgroup.Cgroup
+	// termination requested by the worker
+	termination Termination
+	// timedOut reports whether the job was stopped by the deadline
+	timedOut bool
+	// attemptStart when the current attempt was started
+	attemptStart time.Time
+	// path absolute program path
+	path string
+	// index of the job in the queue, see jobQueue
+	index int
+	// expiry drops the job from the queue when its deadline passes,
+	// nil if the job wasn't queued with a deadline
+	expiry *time.Timer
+	// input read side of the stdin pipe of an interactive job,
+	// shared by the attempts
+	input *os.File
+	// stdin write side of the stdin pipe of an interactive job
+	stdin *os.File
+	// tty pseudo-terminal of the current attempt, nil if the job has no terminal
+	tty *terminal
+	// size last window size of the pseudo-terminal
+	size WindowSize
+	// done is closed when the process is finished
+	done chan struct{}
+	// logging reports whether the log of the job is still written
+	logging bool
+	// processStart process start time, see processStart
+	processStart uint64
+}
+
+// record returns the persistent state of the Job.
+func (j *Job) record() JobRecord {
+	return JobRecord{ID: j.ID, Command: j.Command, Status: *j.Status, CreateTime: j.CreateTime, ProcessStart: j.processStart}
+}
+
+// closeStdin closes the stdin pipe of an interactive Job.
+func (j *Job) closeStdin() {
+	if j.stdin == nil {
+		return
+	}
+	j.stdin.Close()
+	j.input.Close()
 }
 
-// IsRunning checks if the process still running.
+// IsRunning checks if the Job isn't finished yet, see Status.IsRunning.
 func (j *Job) IsRunning() bool {
-	return j.Status.ExitCode == 0 && !j.Status.Exited
+	return j.Status.IsRunning()
 }
 
 // Status of the process.
 type Status struct {
+	// State of the job lifecycle
+	State State
 	// Process identifier
 	Pid int
 	// ExitCode of the exited process, or -1 if the process hasn't
@@ -46,6 +160,116 @@ type Status struct {
 	ExitCode int
 	// Exited reports whether the program has exited
 	Exited bool
+	// Termination reports how the job was terminated by the worker,
+	// set as soon as the stop is requested
+	Termination Termination
+	// Lost reports whether the job outcome is unknown, because the
+	// worker was restarted while the job was running
+	Lost bool
+	// Signal which terminated the process, zero if it exited
+	Signal syscall.Signal
+	// CoreDumped reports whether the terminated process dumped a core
+	CoreDumped bool
+	// StartTime when the job was started, or queued if
+	// it's waiting in the queue
+	StartTime time.Time
+	// EndTime when the job was finished, zero while it runs
+	EndTime time.Time
+	// Duration wall time of the job, from its start until its end, or
+	// until now while it runs
+	Duration time.Duration
+	// Usage resources used by the finished attempts of the job
+	Usage Usage
+	// TimedOut reports whether the job was stopped because its
+	// timeout or deadline has passed
+	TimedOut bool
+	// Attempt number of the current or last attempt, starting from one
+	Attempt int
+	// Attempts results of the finished attempts
+	Attempts []Attempt
+	// Queued reports whether the job is waiting for a free slot,
+	// because the maximum number of running jobs was reached
+	Queued bool
+	// QueuePosition of a queued job, starting from one
+	QueuePosition int
+}
+
+// Usage of the system resources by a job.
+type Usage struct {
+	// UserTime CPU time spent in user mode
+	UserTime time.Duration
+	// SystemTime CPU time spent in kernel mode
+	SystemTime time.Duration
+	// MaxRSS maximum resident set size in bytes
+	MaxRSS int64
+	// InBlock number of block input operations
+	InBlock int64
+	// OutBlock number of block output operations
+	OutBlock int64
+}
+
+// add returns the sum of the usages, the maximum of the MaxRSS.
+func (u Usage) add(other Usage) Usage {
+	sum := Usage{
+		UserTime:   u.UserTime + other.UserTime,
+		SystemTime: u.SystemTime + other.SystemTime,
+		MaxRSS:     u.MaxRSS,
+		InBlock:    u.InBlock + other.InBlock,
+		OutBlock:   u.OutBlock + other.OutBlock,
+	}
+	if other.MaxRSS > sum.MaxRSS {
+		sum.MaxRSS = other.MaxRSS
+	}
+	return sum
+}
+
+// IsRunning checks if the job isn't finished yet, it's pending,
+// running or stopping.
+func (s Status) IsRunning() bool {
+	return !s.State.finished()
+}
+
+// exit returns the exit status of a finished job logged as its last record.
+func (s Status) exit() log.Exit {
+	exit := log.Exit{Code: s.ExitCode, Exited: s.Exited, TimedOut: s.TimedOut, Signal: SignalName(s.Signal)}
+	if s.Termination != TerminationNone {
+		exit.Termination = s.Termination.String()
+	}
+	return exit
+}
+
+// Termination of a job requested by the worker.
+type Termination int
+
+const (
+	// TerminationNone the job wasn't terminated by the worker.
+	TerminationNone Termination = iota
+	// TerminationStopped the job was stopped by a SIGTERM.
+	TerminationStopped
+	// TerminationKilled the job was killed by a SIGKILL, forced or
+	// after the grace period.
+	TerminationKilled
+)
+
+// String returns the termination name.
+func (t Termination) String() string {
+	switch t {
+	case TerminationStopped:
+		return "stopped"
+	case TerminationKilled:
+		return "killed"
+	default:
+		return "none"
+	}
+}
+
+// StopOptions of a stop request.
+type StopOptions struct {
+	// GracePeriod to wait for the job to exit after the SIGTERM, before the
+	// SIGKILL. The worker configuration is used if zero.
+	GracePeriod time.Duration
+	// Force kills the job immediately with a SIGKILL
+	Force bool
 }
 
 // Worker defines the basic operations to manage Jobs.
@@ -56,8 +280,22 @@ type Worker interface {
 	Start(command Command) (jobID string, err error)
 	// Stop a running Job which kills a running process.
 	//    - ID: Job identifier
+	//    - options: grace period and force kill
+	// It returns the execution error encountered.
+	Stop(jobID string, options StopOptions) (err error)
+	// Signal sends a signal to a running Job.
+	//    - ID: Job identifier
+	//    - sig: one of the allowed signals, e.g. SIGHUP or SIGUSR1
+	// It returns the execution error encountered.
+	Signal(jobID string, sig syscall.Signal) (err error)
+	// Pause suspends the processes of a running Job.
+	//    - ID: Job identifier
 	// It returns the execution error encountered.
-	Stop(jobID string) (err error)
+	Pause(jobID string) (err error)
+	// Resume resumes the processes of a paused Job.
+	//    - ID: Job identifier
+	// It returns the execution error encountered.
+	Resume(jobID string) (err error)
 	// Query a Job to check the current status.
 	//    - ID: Job identifier
 	// It returns process status and the execution error
@@ -66,27 +304,111 @@ type Worker interface {
 	// Streams the process output.
 	//    - ctx: context to cancel the log stream
 	//    - ID: Job identifier
+	//    - options: output sources, start time or offset, last lines
+	//      and whether the output is followed
 	// It returns read chan to stream process stdout/stderr and the
-	// execution error encountered.
-	Stream(ctx context.Context, jobID string) (logchan chan string, err error)
+	// execution error encountered. The stream of a finished job ends
+	// with a record carrying the exit status.
+	Stream(ctx context.Context, jobID string, options log.StreamOptions) (logchan chan log.Output, err error)
+	// Attach to an interactive Job, to write to its stdin and
+	// stream its output.
+	//    - ctx: context to cancel the output stream
+	//    - ID: Job identifier
+	// It returns the Job stdin, closing it closes the Job stdin, a
+	// read chan to stream the process output and the execution
+	// error encountered.
+	Attach(ctx context.Context, jobID string) (stdin io.WriteCloser, logchan chan log.Output, err error)
+	// Resize the pseudo-terminal of a Job.
+	//    - ID: Job identifier
+	//    - size: window size
+	// It returns the execution error encountered.
+	Resize(jobID string, size WindowSize) (err error)
+	// List the Jobs ordered by creation time.
+	//    - options: filters and pagination
+	// It returns a page of Job summaries, the token of the next
+	// page, empty if it's the last one, and the execution error
+	// encountered.
+	List(options ListOptions) (jobs []JobSummary, nextPageToken string, err error)
+}
+
+// adoptedPollInterval interval to check if an adopted process is finished.
+const adoptedPollInterval = time.Second
+
+// defaultJanitorInterval interval to purge the logs of finished jobs,
+// if not set in the configuration.
+const defaultJanitorInterval = time.Minute
+
+// defaultStopGracePeriod to wait for a stopped job to exit before killing it,
+// if not set in the configuration.
+const defaultStopGracePeriod = time.Second * 10
+
+// NewWorker creates a new Worker instance. The Jobs are stored in the
+// journal file set in the configuration, or in memory if not set.
+func NewWorker(config conf.Config) (Worker, error) {
+	if config.JobStoreFile == "" {
+		return NewWorkerWithStore(config, NewMemoryStore())
+	}
+	store, err := NewJournalStore(config.JobStoreFile)
+	if err != nil {
+		return nil, err
+	}
+	return NewWorkerWithStore(config, store)
 }
 
-// NewWorker creates a new Worker instance.
-func NewWorker(config conf.Config) Worker {
-	return &worker{
-		logger: log.NewLogger(config),
+// NewWorkerWithStore creates a new Worker instance which persists the Jobs in the given
+// store. The Jobs stored before are loaded, if a Job was running, its process is adopted
+// if still running, otherwise the Job is marked as lost.
+func NewWorkerWithStore(config conf.Config, store JobStore) (Worker, error) {
+	if config.LogJanitorInterval <= 0 {
+		config.LogJanitorInterval = defaultJanitorInterval
+	}
+	if config.StopGracePeriod <= 0 {
+		config.StopGracePeriod = defaultStopGracePeriod
+	}
+	logs, err := log.NewLogStore(config)
+	if err != nil {
+		return nil, err
+	}
+	w := &worker{
+		config: config,
+		logger: logs,
+		store:  store,
 		jobs:   make(map[string]*Job),
 	}
+	records, err := store.Load()
+	if err != nil {
+		return nil, err
+	}
+	// the deadline of a loaded job may pass while the others are loaded
+	w.mtx.Lock()
+	for _, record := range records {
+		w.load(record)
+	}
+	w.dequeue()
+	w.mtx.Unlock()
+	if config.LogRetention > 0 || config.LogCompressAfter > 0 || config.LogFolderBudget > 0 || config.LogArchiveEndpoint != "" {
+		go w.purgeLogs()
+	}
+	return w, nil
 }
 
 // worker implementation.
 type worker struct {
+	// config of the worker
+	config conf.Config
 	// logger is responsible to handle the
 	// stdout and stderr of a running process
-	logger log.Logger
+	logger log.LogStore
+	// store persists the jobs
+	store JobStore
 	// jobs is concurrency safe map to store
 	// the requested jobs
 	jobs map[string]*Job
+	// queue of the jobs waiting for a free slot
+	queue jobQueue
+	// active number of jobs holding a slot, running or
+	// waiting for the next attempt
+	active int
 	// mtx to control jobs concurret access
 	mtx sync.RWMutex
 }
@@ -95,59 +417,808 @@ type worker struct {
 // If the command runs with success, a Job identifier will be returned.
 // A log file will be created with the Job id name to capture the stdout and stderr
 // of a running process.
+// If the command has resource limits, a control group will be created for the Job,
+// and the process will be moved into it before the program runs.
+// If the maximum number of running Jobs was reached, the Job is queued by priority,
+// and started when a slot is freed.
 // To get the process status, the Job request will be stored in memory,
-// and a goroutine will be launched to update the job status when the process is finished.
+// and a goroutine will be launched to update the job status when the process is finished,
+// and to retry it if it fails, as the retry policy allows.
 func (w *worker) Start(command Command) (string, error) {
-	cmd := exec.Command(command.Name, command.Args...)
 	jobID := uuid.NewString()
-	logfile, err := w.logger.Create(jobID)
-	if err != nil {
+	if deadline := command.deadline(time.Now()); !deadline.IsZero() && !deadline.After(time.Now()) {
+		return jobID, errors.New("the deadline has already passed")
+	}
+	if err := command.Retry.validate(); err != nil {
 		return jobID, err
 	}
-	// redirect the stdout and stderr to the log file
-	cmd.Stdout = logfile
-	cmd.Stderr = logfile
-	if err = cmd.Start(); err != nil {
-		w.logger.Remove(jobID)
+	for _, kv := range command.Env {
+		if strings.Index(kv, "=") < 1 {
+			return jobID, fmt.Errorf("invalid environment variable %q, expected key=value", kv)
+		}
+	}
+	if command.Dir != "" {
+		if info, err := os.Stat(command.Dir); err != nil {
+			return jobID, err
+		} else if !info.IsDir() {
+			return jobID, fmt.Errorf("%v is not a directory", command.Dir)
+		}
+	}
+	if command.User != "" {
+		if _, err := credential(command.User); err != nil {
+			return jobID, err
+		}
+	}
+	path, err := lookPath(command.Name, command.Dir, mergeEnv(baseEnv(w.config), command.Env))
+	if err != nil {
 		return jobID, err
 	}
-	// create and store the job
-	job := Job{ID: jobID, Cmd: cmd, Status: &Status{Pid: cmd.Process.Pid}}
+	now := time.Now()
+	job := &Job{
+		ID:         jobID,
+		Command:    command,
+		Status:     &Status{State: StatePending, StartTime: now},
+		CreateTime: now,
+		done:       make(chan struct{}),
+		path:       path,
+	}
+	if command.Interactive {
+		if job.input, job.stdin, err = os.Pipe(); err != nil {
+			return jobID, err
+		}
+		// the terminal of each attempt is written from the stdin pipe
+		if command.TTY {
+			go w.forwardInput(job)
+		}
+	}
 	w.mtx.Lock()
-	w.jobs[jobID] = &job
+	if w.full() {
+		job.Status.Queued = true
+		w.queue.push(job)
+		w.watchQueued(job)
+		w.jobs[jobID] = job
+		w.save(job)
+		w.mtx.Unlock()
+		return jobID, nil
+	}
+	// the slot is reserved while the job is started
+	w.active++
 	w.mtx.Unlock()
+	logs, err := w.begin(job)
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	if err != nil {
+		job.closeStdin()
+		w.release()
+		return jobID, err
+	}
+	w.jobs[jobID] = job
+	w.save(job)
+	w.watchDeadline(job)
 	// update the job status in background
-	go func() {
+	go w.wait(job, logs)
+	return jobID, nil
+}
+
+// full checks if the maximum number of running Jobs was reached.
+// It must be called holding the lock.
+func (w *worker) full() bool {
+	return w.config.MaxConcurrentJobs > 0 && w.active >= w.config.MaxConcurrentJobs
+}
+
+// release frees the slot of a finished Job, and starts the queued Jobs.
+// It must be called holding the lock.
+func (w *worker) release() {
+	w.active--
+	w.dequeue()
+}
+
+// dequeue starts the queued Jobs by priority while there are free slots.
+// It must be called holding the lock, or before the worker is shared.
+func (w *worker) dequeue() {
+	for w.queue.Len() > 0 && !w.full() {
+		job := w.queue.pop()
+		job.unqueue()
+		// the timeout counts from the start, the deadline may pass before the expiry runs
+		if !job.Command.Deadline.IsZero() && !job.Command.Deadline.After(time.Now()) {
+			job.timedOut = true
+			w.drop(job)
+			continue
+		}
+		logs, err := w.begin(job)
+		if err != nil {
+			logger.Printf("Fail to start the queued job %v, %v", job.ID, err)
+			w.drop(job)
+			continue
+		}
+		w.active++
+		w.save(job)
+		w.watchDeadline(job)
+		go w.wait(job, logs)
+	}
+}
+
+// drop finishes a queued Job which will never be started. It must be
+// called holding the lock.
+func (w *worker) drop(job *Job) {
+	job.Status = &Status{
+		State:       job.Status.State,
+		ExitCode:    -1,
+		Termination: job.termination,
+		StartTime:   job.Status.StartTime,
+		EndTime:     time.Now(),
+		TimedOut:    job.timedOut,
+	}
+	w.setState(job, finalState(*job.Status))
+	job.closeStdin()
+	close(job.done)
+	w.save(job)
+}
+
+// begin creates the log file of a Job and starts its first attempt.
+// It returns the log writer, which is shared by all attempts.
+func (w *worker) begin(job *Job) (*log.Writer, error) {
+	logs, err := w.logger.Create(job.ID, func() { w.logLimit(job) })
+	if err != nil {
+		return nil, err
+	}
+	proc, cg, tty, err := w.launch(job, logs)
+	if err != nil {
+		logs.Close()
+		w.cleanup(job.ID)
+		return nil, err
+	}
+	now := time.Now()
+	cmd := proc.cmd
+	job.Cmd = cmd
+	job.init = proc
+	job.Cgroup = cg
+	job.tty = tty
+	job.attemptStart = now
+	job.logging = true
+	job.Status = &Status{State: job.Status.State, Pid: cmd.Process.Pid, StartTime: now, Attempt: 1}
+	w.setState(job, StateRunning)
+	if job.processStart, err = processStart(cmd.Process.Pid); err != nil {
+		logger.Printf("Fail to read the process start time, %v", err)
+	}
+	return logs, nil
+}
+
+// launch starts an attempt of the Job program, with the stdout and stderr
+// written as log records, and the stdin from the Job input pipe if it's
+// interactive. A Job with terminal runs with a new pseudo-terminal instead,
+// whose output is written as stdout records. If the command has resource limits,
+// a control group is created for the attempt, and the process is moved into it
+// before the program runs.
+func (w *worker) launch(job *Job, logs *log.Writer) (*initProcess, *cgroup.Cgroup, *terminal, error) {
+	command := job.Command
+	var cred *syscall.Credential
+	var err error
+	if command.User != "" {
+		if cred, err = credential(command.User); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	var tty *terminal
+	var slave *os.File
+	if command.TTY {
+		var master *os.File
+		if master, slave, err = openTerminal(); err != nil {
+			return nil, nil, nil, err
+		}
+		tty = &terminal{master: master, done: make(chan struct{})}
+		if job.size != (WindowSize{}) {
+			if err := tty.resize(job.size); err != nil {
+				logger.Printf("Fail to resize the terminal, %v", err)
+			}
+		}
+	}
+	// closeTerminal releases the terminal if the job isn't started
+	closeTerminal := func() {
+		if slave != nil {
+			slave.Close()
+		}
+		if tty != nil {
+			tty.master.Close()
+		}
+	}
+	var cg *cgroup.Cgroup
+	if !command.Limits.IsZero() {
+		if cg, err = cgroup.New(w.config.CgroupFolder, job.ID, command.Limits); err != nil {
+			closeTerminal()
+			return nil, nil, nil, err
+		}
+	}
+	isolated := w.isolated(command)
+	proc, err := newInitProcess(isolated)
+	if err != nil {
+		closeTerminal()
+		removeCgroup(cg)
+		return nil, nil, nil, err
+	}
+	cmd := proc.cmd
+	if tty != nil {
+		proc.attachTerminal(slave)
+	} else {
+		// the stdout and stderr are copied to the log by the command
+		cmd.Stdout = logs.Stream(log.Stdout)
+		cmd.Stderr = logs.Stream(log.Stderr)
+		if job.input != nil {
+			cmd.Stdin = job.input
+		}
+	}
+	if err = proc.start(); err != nil {
+		closeTerminal()
+		removeCgroup(cg)
+		return nil, nil, nil, err
+	}
+	if tty != nil {
+		// the slave side belongs to the init process now
+		slave.Close()
+		slave = nil
+		go tty.copyOutput(logs.Stream(log.Stdout))
+	}
+	// the process is placed in the control group before the program runs
+	if cg != nil {
+		if err = cg.AddProcess(cmd.Process.Pid); err != nil {
+			proc.abort()
+			closeTerminal()
+			removeCgroup(cg)
+			return nil, nil, nil, err
+		}
+	}
+	spec := initSpec{
+		Path:       job.path,
+		Args:       append([]string{command.Name}, command.Args...),
+		Isolated:   isolated,
+		Hostname:   job.ID,
+		TTY:        tty != nil,
+		Env:        mergeEnv(baseEnv(w.config), command.Env),
+		Dir:        command.Dir,
+		Credential: cred,
+	}
+	if err = proc.exec(spec); err != nil {
+		proc.abort()
+		closeTerminal()
+		removeCgroup(cg)
+		return nil, nil, nil, err
+	}
+	return proc, cg, tty, nil
+}
+
+// wait waits for the Job attempts to finish and updates the Job status. A failed
+// attempt is retried after the backoff, as the retry policy allows, unless the
+// Job was stopped by the worker.
+func (w *worker) wait(job *Job, logs *log.Writer) {
+	defer func() {
+		w.mtx.RLock()
+		exit := job.Status.exit()
+		w.mtx.RUnlock()
+		if err := logs.Finish(exit); err != nil {
+			logger.Printf("Fail to close the log file, %v", err)
+		}
+		w.mtx.Lock()
+		job.logging = false
+		w.mtx.Unlock()
+	}()
+	for {
 		if err := job.Cmd.Wait(); err != nil {
 			logger.Printf("Command execution fails, %v", err)
 		}
+		// the terminal output is copied until the job children exit as well
+		if job.tty != nil {
+			<-job.tty.done
+			job.tty.master.Close()
+		}
+		removeCgroup(job.Cgroup)
 		// update the job status with information about
 		// the exited process
-		status := Status{
-			Pid:      job.Cmd.ProcessState.Pid(),
-			ExitCode: job.Cmd.ProcessState.ExitCode(),
-			Exited:   job.Cmd.ProcessState.Exited(),
-		}
 		w.mtx.Lock()
-		job.Status = &status
+		state := job.Cmd.ProcessState
+		attempt := Attempt{
+			Pid:       state.Pid(),
+			ExitCode:  state.ExitCode(),
+			Exited:    state.Exited(),
+			StartTime: job.attemptStart,
+			EndTime:   time.Now(),
+			Usage:     processUsage(state),
+		}
+		attempt.Signal, attempt.CoreDumped = exitSignal(state)
+		// the init process of an isolated job reports how the job program exited
+		if status, ok := job.init.programStatus(); ok {
+			attempt.ExitCode = status.ExitStatus()
+			attempt.Exited = status.Exited()
+			attempt.Signal, attempt.CoreDumped = waitSignal(status)
+		}
+		job.Status = &Status{
+			State:     job.Status.State,
+			Pid:       attempt.Pid,
+			StartTime: job.Status.StartTime,
+			Usage:     job.Status.Usage.add(attempt.Usage),
+			Attempt:   job.Status.Attempt,
+			Attempts:  append(append([]Attempt(nil), job.Status.Attempts...), attempt),
+		}
+		policy := job.Command.Retry
+		if job.termination != TerminationNone || job.timedOut || !policy.retryable(job.Status.Attempt, attempt.ExitCode) {
+			w.finish(job)
+			w.mtx.Unlock()
+			return
+		}
+		delay := policy.delay(job.Status.Attempt)
+		w.setState(job, StatePending)
+		w.save(job)
 		w.mtx.Unlock()
+		if !w.retry(job, logs, delay) {
+			return
+		}
+	}
+}
+
+// retry waits for the backoff and starts the next attempt of the Job. It returns
+// false if the Job is finished, because it was stopped while waiting, or the
+// attempt failed to start.
+func (w *worker) retry(job *Job, logs *log.Writer, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-job.done:
+		return false
+	case <-timer.C:
+	}
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	// the job may have been stopped while waiting for the lock
+	if job.Status.State != StatePending {
+		return false
+	}
+	proc, cg, tty, err := w.launch(job, logs)
+	if err != nil {
+		logger.Printf("Fail to retry the job %v, %v", job.ID, err)
+		w.finish(job)
+		return false
+	}
+	cmd := proc.cmd
+	job.Cmd = cmd
+	job.init = proc
+	job.Cgroup = cg
+	job.tty = tty
+	job.attemptStart = time.Now()
+	job.Status = &Status{
+		State:     job.Status.State,
+		Pid:       cmd.Process.Pid,
+		StartTime: job.Status.StartTime,
+		Usage:     job.Status.Usage,
+		Attempt:   job.Status.Attempt + 1,
+		Attempts:  job.Status.Attempts,
+	}
+	w.setState(job, StateRunning)
+	if job.processStart, err = processStart(cmd.Process.Pid); err != nil {
+		logger.Printf("Fail to read the process start time, %v", err)
+	}
+	w.save(job)
+	return true
+}
+
+// finish sets the final status of the Job, given the result of the last
+// attempt, and frees its slot. It must be called holding the lock.
+func (w *worker) finish(job *Job) {
+	last := job.Status.Attempts[len(job.Status.Attempts)-1]
+	job.Status = &Status{
+		State:       job.Status.State,
+		Pid:         last.Pid,
+		ExitCode:    last.ExitCode,
+		Exited:      last.Exited,
+		Termination: job.termination,
+		Signal:      last.Signal,
+		CoreDumped:  last.CoreDumped,
+		StartTime:   job.Status.StartTime,
+		EndTime:     last.EndTime,
+		Duration:    last.EndTime.Sub(job.Status.StartTime),
+		Usage:       job.Status.Usage,
+		TimedOut:    job.timedOut,
+		Attempt:     job.Status.Attempt,
+		Attempts:    job.Status.Attempts,
+	}
+	w.setState(job, finalState(*job.Status))
+	job.closeStdin()
+	close(job.done)
+	w.save(job)
+	w.release()
+}
+
+// load restores a stored Job. A running Job is adopted if its process
+// still running, otherwise it's marked as lost. The output of an adopted
+// Job isn't logged anymore, since the log records were written by the
+// worker which started it.
+func (w *worker) load(record JobRecord) {
+	status := record.Status
+	if status.State == stateUnknown {
+		status.State = legacyState(status)
+	}
+	job := &Job{
+		ID:           record.ID,
+		Command:      record.Command,
+		Status:       &status,
+		CreateTime:   record.CreateTime,
+		termination:  status.Termination,
+		timedOut:     status.TimedOut,
+		done:         make(chan struct{}),
+		processStart: record.ProcessStart,
+	}
+	// the records stored before the creation time have the start time
+	if job.CreateTime.IsZero() {
+		job.CreateTime = status.StartTime
+	}
+	w.jobs[job.ID] = job
+	if !job.IsRunning() {
+		close(job.done)
+		return
+	}
+	if status.Queued {
+		w.enqueue(job)
+		return
+	}
+	if record.ProcessStart == 0 || !isProcessAlive(status.Pid, record.ProcessStart) {
+		w.lose(job)
+		return
+	}
+	if !job.Command.Limits.IsZero() {
+		job.Cgroup = cgroup.Open(w.config.CgroupFolder, job.ID)
+	}
+	w.active++
+	go w.watchAdopted(job)
+	w.watchDeadline(job)
+}
+
+// watchDeadline stops the Job when its deadline passes, using the same
+// termination path of the Stop. It must be called holding the lock, or
+// before the worker is shared.
+func (w *worker) watchDeadline(job *Job) {
+	deadline := job.Command.deadline(job.Status.StartTime)
+	if deadline.IsZero() {
+		return
+	}
+	go func() {
+		timer := time.NewTimer(time.Until(deadline))
+		defer timer.Stop()
+		select {
+		case <-job.done:
+		case <-timer.C:
+			w.mtx.Lock()
+			defer w.mtx.Unlock()
+			if !job.IsRunning() {
+				return
+			}
+			job.timedOut = true
+			if err := w.stop(job, StopOptions{}); err != nil {
+				logger.Printf("Fail to stop the timed out job %v, %v", job.ID, err)
+			}
+		}
 	}()
-	return jobID, nil
 }
 
-// Stop terminates a running Job gracefully sending a SIGTERM to the process.
+// watchAdopted waits for an adopted process to finish. The process isn't
+// a child of the worker, so it can't be waited and its exit status is lost.
+func (w *worker) watchAdopted(job *Job) {
+	// the job is updated holding the lock
+	w.mtx.RLock()
+	pid, start, cg := job.Status.Pid, job.processStart, job.Cgroup
+	w.mtx.RUnlock()
+	ticker := time.NewTicker(adoptedPollInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if !isProcessAlive(pid, start) {
+			break
+		}
+	}
+	removeCgroup(cg)
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	w.lose(job)
+	w.release()
+}
+
+// enqueue restores a stored queued Job, the program path is looked up
+// again. It must be called before the worker is shared.
+func (w *worker) enqueue(job *Job) {
+	path, err := lookPath(job.Command.Name, job.Command.Dir, mergeEnv(baseEnv(w.config), job.Command.Env))
+	if err != nil {
+		logger.Printf("Fail to restore the queued job %v, %v", job.ID, err)
+		w.drop(job)
+		return
+	}
+	job.path = path
+	w.queue.push(job)
+	w.watchQueued(job)
+}
+
+// watchQueued drops a queued Job when its deadline passes, the timeout
+// counts from the start. It must be called holding the lock.
+func (w *worker) watchQueued(job *Job) {
+	if job.Command.Deadline.IsZero() {
+		return
+	}
+	job.expiry = time.AfterFunc(time.Until(job.Command.Deadline), func() {
+		w.mtx.Lock()
+		defer w.mtx.Unlock()
+		if !job.Status.Queued {
+			return
+		}
+		w.queue.remove(job)
+		job.unqueue()
+		job.timedOut = true
+		w.drop(job)
+	})
+}
+
+// unqueue stops the expiry of a Job removed from the queue.
+func (j *Job) unqueue() {
+	if j.expiry != nil {
+		j.expiry.Stop()
+		j.expiry = nil
+	}
+}
+
+// lose marks a Job as lost. It must be called holding the lock,
+// or before the worker is shared.
+func (w *worker) lose(job *Job) {
+	job.Status = &Status{
+		State:       job.Status.State,
+		Pid:         job.Status.Pid,
+		ExitCode:    -1,
+		Termination: job.termination,
+		Lost:        true,
+		StartTime:   job.Status.StartTime,
+		Usage:       job.Status.Usage,
+		TimedOut:    job.timedOut,
+		Attempt:     job.Status.Attempt,
+		Attempts:    job.Status.Attempts,
+	}
+	w.setState(job, StateLost)
+	close(job.done)
+	w.save(job)
+}
+
+// setState moves the Job to the next state, an invalid transition is
+// logged and ignored. It must be called holding the lock.
+func (w *worker) setState(job *Job, state State) {
+	if err := job.transition(state); err != nil {
+		logger.Printf("Fail to update the job state, %v", err)
+	}
+}
+
+// save persists the Job record. It must be called holding the lock.
+func (w *worker) save(job *Job) {
+	if err := w.store.Save(job.record()); err != nil {
+		logger.Printf("Fail to save the job %v, %v", job.ID, err)
+	}
+}
+
+// isolated checks if the job runs in new namespaces.
+func (w *worker) isolated(command Command) bool {
+	switch command.Isolation {
+	case IsolationNone:
+		return false
+	case IsolationNamespaces:
+		return true
+	default:
+		return w.config.NamespaceIsolation
+	}
+}
+
+// cleanup removes the log file of a Job which failed to start.
+func (w *worker) cleanup(jobID string) {
+	if err := w.logger.Remove(jobID); err != nil && !os.IsNotExist(err) {
+		logger.Printf("Fail to remove the log file, %v", err)
+	}
+}
+
+// removeCgroup removes a job control group, if any.
+func removeCgroup(cg *cgroup.Cgroup) {
+	if cg == nil {
+		return
+	}
+	if err := cg.Remove(); err != nil {
+		logger.Printf("Fail to remove the control group, %v", err)
+	}
+}
+
+// Stop terminates a running Job gracefully sending a SIGTERM to the process group,
+// so the child processes are terminated as well. If the job is still running after
+// the grace period, or if forced, a SIGKILL will be sent to the process group.
+// A job waiting for the next attempt is finished without retrying.
+// A queued job is removed from the queue.
 // If the job doesn't exitis an error will be returned.
-func (w *worker) Stop(jobID string) error {
+func (w *worker) Stop(jobID string, options StopOptions) error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	job, err := w.getJob(jobID)
+	if err != nil {
+		return err
+	}
+	return w.stop(job, options)
+}
+
+// stop terminates a running Job. It must be called holding the lock.
+func (w *worker) stop(job *Job, options StopOptions) error {
+	if !job.IsRunning() {
+		return errors.New("the process is already finished")
+	}
+	if job.Status.Queued {
+		w.queue.remove(job)
+		job.unqueue()
+		job.termination = TerminationStopped
+		w.drop(job)
+		return nil
+	}
+	// there is no process while waiting for the next attempt
+	if job.Status.State == StatePending {
+		job.termination = TerminationStopped
+		w.finish(job)
+		return nil
+	}
+	if options.Force {
+		return w.kill(job)
+	}
+	if err := signalGroup(job, syscall.SIGTERM); err != nil {
+		return err
+	}
+	if job.termination == TerminationNone {
+		job.termination = TerminationStopped
+	}
+	w.stopping(job)
+	grace := options.GracePeriod
+	if grace <= 0 {
+		grace = w.config.StopGracePeriod
+	}
+	// escalates to SIGKILL if the job doesn't exit in time
+	go func() {
+		timer := time.NewTimer(grace)
+		defer timer.Stop()
+		select {
+		case <-job.done:
+		case <-timer.C:
+			w.mtx.Lock()
+			defer w.mtx.Unlock()
+			if job.IsRunning() {
+				if err := w.kill(job); err != nil {
+					logger.Printf("Fail to kill the job %v, %v", job.ID, err)
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// kill sends a SIGKILL to the job process group, and to any process
+// left in the job control group. It must be called holding the lock.
+func (w *worker) kill(job *Job) error {
+	job.termination = TerminationKilled
+	if err := signalGroup(job, syscall.SIGKILL); err != nil {
+		return err
+	}
+	w.stopping(job)
+	// the processes which left the process group are still in the control group
+	if job.Cgroup != nil {
+		if err := job.Cgroup.Kill(); err != nil {
+			logger.Printf("Fail to kill the control group processes, %v", err)
+		}
+	}
+	return nil
+}
+
+// stopping reports the termination requested for a signaled Job, and moves
+// the Job to the stopping state, until its process exits. A paused Job is
+// resumed, so its processes handle the signal. It must be called holding
+// the lock.
+func (w *worker) stopping(job *Job) {
+	job.Status.Termination = job.termination
+	switch job.Status.State {
+	case StatePaused:
+		if err := thaw(job); err != nil {
+			logger.Printf("Fail to resume the job %v, %v", job.ID, err)
+		}
+		w.setState(job, StateStopping)
+	case StateRunning:
+		w.setState(job, StateStopping)
+	}
+	w.save(job)
+}
+
+// signalGroup sends a signal to the job process group.
+func signalGroup(job *Job, sig syscall.Signal) error {
+	// the job process is the process group leader
+	err := syscall.Kill(-job.Status.Pid, sig)
+	if err == syscall.ESRCH {
+		return errors.New("the process is already finished")
+	}
+	return err
+}
+
+// Signal sends a signal to the process group of a running, paused or stopping
+// Job, so the child processes receive it as well. Just the allowed signals
+// are sent, a paused Job receives them once resumed, except the SIGKILL.
+// If the job doesn't exist an error will be returned.
+func (w *worker) Signal(jobID string, sig syscall.Signal) error {
+	if !allowedSignals[sig] {
+		return fmt.Errorf("the signal %v is not allowed", SignalName(sig))
+	}
 	w.mtx.RLock()
 	defer w.mtx.RUnlock()
 	job, err := w.getJob(jobID)
 	if err != nil {
 		return err
 	}
-	if job.IsRunning() {
-		return job.Cmd.Process.Signal(syscall.SIGTERM)
+	switch job.Status.State {
+	case StateRunning, StatePaused, StateStopping:
+		return signalGroup(job, sig)
+	default:
+		return fmt.Errorf("the job can't be signaled in the %v state", job.Status.State)
 	}
-	return errors.New("the process is already finished")
+}
+
+// Pause suspends the processes of a running Job, with the control group
+// freezer if the Job has a control group, or a SIGSTOP to its process group
+// otherwise, see freeze. The timeout and deadline still apply to a paused Job.
+// If the job doesn't exist an error will be returned.
+func (w *worker) Pause(jobID string) error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	job, err := w.getJob(jobID)
+	if err != nil {
+		return err
+	}
+	if job.Status.State != StateRunning {
+		return fmt.Errorf("the job can't be paused in the %v state", job.Status.State)
+	}
+	if err := freeze(job); err != nil {
+		return err
+	}
+	w.setState(job, StatePaused)
+	w.save(job)
+	return nil
+}
+
+// Resume resumes the processes of a paused Job, the same way they were paused.
+// If the job doesn't exist an error will be returned.
+func (w *worker) Resume(jobID string) error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	job, err := w.getJob(jobID)
+	if err != nil {
+		return err
+	}
+	if job.Status.State != StatePaused {
+		return fmt.Errorf("the job can't be resumed in the %v state", job.Status.State)
+	}
+	if err := thaw(job); err != nil {
+		return err
+	}
+	w.setState(job, StateRunning)
+	w.save(job)
+	return nil
+}
+
+// freeze suspends the job processes, with the control group freezer,
+// or a SIGSTOP to the process group if the job has no control group.
+// The init process of an isolated job receives a SIGTSTP instead,
+// which it forwards as a SIGSTOP to the job program.
+func freeze(job *Job) error {
+	if job.Cgroup != nil {
+		return job.Cgroup.Freeze()
+	}
+	if job.init != nil && job.init.isolated {
+		return signalGroup(job, syscall.SIGTSTP)
+	}
+	return signalGroup(job, syscall.SIGSTOP)
+}
+
+// thaw resumes the job processes suspended by freeze.
+func thaw(job *Job) error {
+	if job.Cgroup != nil {
+		return job.Cgroup.Thaw()
+	}
+	return signalGroup(job, syscall.SIGCONT)
 }
 
 // Query returns the process status of a specific Job.
@@ -159,20 +1230,176 @@ func (w *worker) Query(jobID string) (Status, error) {
 	if err != nil {
 		return Status{}, err
 	}
-	return *job.Status, nil
+	status := *job.Status
+	if status.Queued {
+		status.QueuePosition = w.queue.position(job)
+	} else if status.IsRunning() {
+		status.Duration = time.Since(status.StartTime)
+	}
+	return status, nil
 }
 
-// Stream reads from the log file, like 'tail -f' through
-// a channel. If the context is canceled the channel will
-// be closed and the tailing will be stopped.
-func (w *worker) Stream(ctx context.Context, jobID string) (chan string, error) {
+// Stream reads the records from the log file, like 'tail -f'
+// through a channel. If the context is canceled the channel will
+// be closed and the tailing will be stopped. The stream ends with
+// the exit status once the job is finished.
+func (w *worker) Stream(ctx context.Context, jobID string, options log.StreamOptions) (chan log.Output, error) {
 	w.mtx.RLock()
 	job, err := w.getJob(jobID)
 	w.mtx.RUnlock()
 	if err != nil {
 		return nil, err
 	}
-	return w.logger.Tailf(ctx, job.ID)
+	records, err := w.logger.Tailf(ctx, job.ID, options)
+	if err != nil {
+		return nil, err
+	}
+	logchan := make(chan log.Output)
+	go func() {
+		defer close(logchan)
+		last := log.Output{Offset: options.Offset}
+		for record := range records {
+			select {
+			case logchan <- record:
+			case <-ctx.Done():
+			}
+			if record.Exit != nil {
+				return
+			}
+			last = record
+		}
+		// the log misses the exit status if it was streamed from past its
+		// end, or written before a restart, it's sent once the job is finished
+		w.mtx.RLock()
+		finished := !job.IsRunning()
+		exit := job.Status.exit()
+		w.mtx.RUnlock()
+		if !finished || ctx.Err() != nil {
+			return
+		}
+		select {
+		case logchan <- log.Output{Time: time.Now(), Offset: last.Offset, Exit: &exit}:
+		case <-ctx.Done():
+		}
+	}()
+	return logchan, nil
+}
+
+// Attach returns the stdin of an interactive Job, and streams its output like
+// Stream. The stdin is shared by all attached clients.
+func (w *worker) Attach(ctx context.Context, jobID string) (io.WriteCloser, chan log.Output, error) {
+	w.mtx.RLock()
+	job, err := w.getJob(jobID)
+	if err == nil {
+		switch {
+		case !job.Command.Interactive:
+			err = errors.New("the job is not interactive")
+		case job.stdin == nil:
+			err = errors.New("the job stdin isn't available after a restart")
+		case !job.IsRunning():
+			err = errors.New("the process is already finished")
+		case job.Status.Queued:
+			err = errors.New("the job is queued")
+		}
+	}
+	w.mtx.RUnlock()
+	if err != nil {
+		return nil, nil, err
+	}
+	logchan, err := w.logger.Tailf(ctx, job.ID, log.StreamOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	return job.stdin, logchan, nil
+}
+
+// Resize sets the window size of the Job pseudo-terminal. The size is
+// kept for the next attempts.
+func (w *worker) Resize(jobID string, size WindowSize) error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	job, err := w.getJob(jobID)
+	if err != nil {
+		return err
+	}
+	if !job.Command.TTY {
+		return errors.New("the job has no terminal")
+	}
+	if !job.IsRunning() {
+		return errors.New("the process is already finished")
+	}
+	job.size = size
+	// the size is applied when the next attempt starts
+	if job.Status.State == StatePending {
+		return nil
+	}
+	if job.tty == nil {
+		return errors.New("the job terminal isn't available after a restart")
+	}
+	return job.tty.resize(size)
+}
+
+// forwardInput writes the stdin pipe of an interactive Job with terminal to the
+// terminal of the current attempt. The end of the input is sent as an EOT
+// character, the end of file of a terminal in canonical mode.
+func (w *worker) forwardInput(job *Job) {
+	buf := make([]byte, 1024)
+	for {
+		nbytes, err := job.input.Read(buf)
+		input := buf[:nbytes]
+		if err == io.EOF {
+			input = []byte{4}
+		}
+		if len(input) > 0 {
+			w.mtx.RLock()
+			tty := job.tty
+			w.mtx.RUnlock()
+			if tty != nil {
+				if _, err := tty.master.Write(input); err != nil {
+					logger.Printf("Fail to write the job terminal, %v", err)
+				}
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// logLimit kills a Job whose log reached the maximum size.
+func (w *worker) logLimit(job *Job) {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	if !job.IsRunning() {
+		return
+	}
+	logger.Printf("The log of the job %v reached the maximum size", job.ID)
+	if err := w.stop(job, StopOptions{Force: true}); err != nil {
+		logger.Printf("Fail to kill the job %v, %v", job.ID, err)
+	}
+}
+
+// purgeLogs compresses, archives and deletes the logs of the finished Jobs periodically.
+func (w *worker) purgeLogs() {
+	ticker := time.NewTicker(w.config.LogJanitorInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if err := w.logger.Purge(w.logState); err != nil {
+			logger.Printf("Fail to purge the logs, %v", err)
+		}
+	}
+}
+
+// logState tells if a log belongs to a Job, and if the Job is finished and its
+// log closed. A Job waiting for the next attempt still writes its log.
+func (w *worker) logState(name string) (bool, bool) {
+	w.mtx.RLock()
+	defer w.mtx.RUnlock()
+	job, ok := w.jobs[name]
+	if !ok {
+		return false, false
+	}
+	return true, !job.IsRunning() && !job.logging
 }
 
 // getJob helper to get a job given an id.
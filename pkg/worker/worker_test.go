@@ -2,14 +2,76 @@ package worker
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
 	"testing"
 	"time"
 
+	"github.com/renatoaguimaraes/job-scheduler/pkg/worker/cgroup"
 	"github.com/renatoaguimaraes/job-scheduler/pkg/worker/conf"
+	"github.com/renatoaguimaraes/job-scheduler/pkg/worker/log"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
-var w = NewWorker(conf.NewConfig())
+var w = newTestWorker(conf.NewConfig())
+
+// newTestWorker creates a worker or panics.
+func newTestWorker(config conf.Config) Worker {
+	w, err := NewWorker(config)
+	if err != nil {
+		panic(err)
+	}
+	return w
+}
+
+// readOutput reads the Job output from the log records, just
+// from the given sources if any.
+func readOutput(w Worker, jobID string, sources ...log.Source) (string, error) {
+	file, err := w.(*worker).logger.Open(jobID, 0)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	var output strings.Builder
+	decoder := json.NewDecoder(file)
+	for decoder.More() {
+		var record log.Output
+		if err := decoder.Decode(&record); err != nil {
+			return "", err
+		}
+		if len(sources) == 0 || record.Source == sources[0] {
+			output.WriteString(record.Data)
+		}
+	}
+	return output.String(), nil
+}
+
+// waitFinished polls the Job status until the Job is finished.
+func waitFinished(t *testing.T, w Worker, jobID string) Status {
+	deadline := time.Now().Add(time.Second * 10)
+	for time.Now().Before(deadline) {
+		st, err := w.Query(jobID)
+		require.NoError(t, err)
+		if !st.IsRunning() {
+			return st
+		}
+		time.Sleep(time.Millisecond * 50)
+	}
+	t.Fatal("the job isn't finished")
+	return Status{}
+}
+
+func TestMain(m *testing.M) {
+	Init()
+	os.Exit(m.Run())
+}
 
 func TestStartExistingCommand(t *testing.T) {
 	jobID, err := w.Start(Command{Name: "ls"})
@@ -26,7 +88,7 @@ func TestStartNotExistingCommand(t *testing.T) {
 }
 
 func TestStopNotExistingProcess(t *testing.T) {
-	err := w.Stop("notexists")
+	err := w.Stop("notexists", StopOptions{})
 	assert.Equal(t, "Job notexists not found", err.Error())
 }
 
@@ -34,7 +96,7 @@ func TestStopExistingProcess(t *testing.T) {
 	jobID, err := w.Start(Command{Name: "sleep", Args: []string{"2"}})
 	assert.NoError(t, err)
 
-	err = w.Stop(jobID)
+	err = w.Stop(jobID, StopOptions{})
 	assert.NoError(t, err)
 }
 
@@ -44,7 +106,7 @@ func TestStopStoppedProcess(t *testing.T) {
 
 	time.Sleep(time.Second * 2)
 
-	err = w.Stop(jobID)
+	err = w.Stop(jobID, StopOptions{})
 	assert.Error(t, err)
 }
 
@@ -56,6 +118,7 @@ func TestQueryExistingProcess(t *testing.T) {
 	assert.False(t, st.Exited)
 	assert.Zero(t, st.ExitCode)
 	assert.NoError(t, err)
+	assert.True(t, st.EndTime.IsZero())
 
 	time.Sleep(time.Second * 2)
 
@@ -63,13 +126,17 @@ func TestQueryExistingProcess(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, st.Exited)
 	assert.Zero(t, st.ExitCode)
+	assert.Zero(t, st.Signal)
+	assert.Equal(t, st.EndTime.Sub(st.StartTime), st.Duration)
+	assert.GreaterOrEqual(t, int64(st.Duration), int64(time.Second))
+	assert.Positive(t, st.Usage.MaxRSS)
 }
 
 func TestQueryStoppedProcess(t *testing.T) {
 	jobID, err := w.Start(Command{Name: "sleep", Args: []string{"1"}})
 	assert.NoError(t, err)
 
-	err = w.Stop(jobID)
+	err = w.Stop(jobID, StopOptions{})
 	assert.NoError(t, err)
 
 	time.Sleep(time.Second * 2)
@@ -78,6 +145,10 @@ func TestQueryStoppedProcess(t *testing.T) {
 	assert.NoError(t, err)
 	assert.False(t, st.Exited)
 	assert.Equal(t, -1, st.ExitCode)
+	assert.Equal(t, TerminationStopped, st.Termination)
+	assert.Equal(t, syscall.SIGTERM, st.Signal)
+	assert.False(t, st.CoreDumped)
+	assert.Equal(t, "SIGTERM", SignalName(st.Signal))
 }
 
 func TestQueryNotExistingProcess(t *testing.T) {
@@ -92,19 +163,918 @@ func TestStreamExistingProcess(t *testing.T) {
 	assert.Nil(t, err, "err should be nil")
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-	logchan, err := w.Stream(ctx, jobID)
+	logchan, err := w.Stream(ctx, jobID, log.StreamOptions{})
 	assert.Nil(t, err, "err should be nil")
 	assert.NotNil(t, <-logchan)
 	cancel()
 
-	err = w.Stop(jobID)
+	err = w.Stop(jobID, StopOptions{})
 	assert.NoError(t, err)
 }
 
+func TestStreamSources(t *testing.T) {
+	jobID, err := w.Start(Command{Name: "sh", Args: []string{"-c", "echo out; echo err >&2"}})
+	require.NoError(t, err)
+
+	time.Sleep(time.Millisecond * 200)
+
+	stdout, err := readOutput(w, jobID, log.Stdout)
+	require.NoError(t, err)
+	assert.Equal(t, "out\n", stdout)
+	stderr, err := readOutput(w, jobID, log.Stderr)
+	require.NoError(t, err)
+	assert.Equal(t, "err\n", stderr)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	logchan, err := w.Stream(ctx, jobID, log.StreamOptions{Sources: []log.Source{log.Stderr}})
+	require.NoError(t, err)
+	record := <-logchan
+	assert.Equal(t, log.Stderr, record.Source)
+	assert.Equal(t, "err\n", record.Data)
+}
+
+func TestStreamTail(t *testing.T) {
+	jobID, err := w.Start(Command{Name: "seq", Args: []string{"5"}})
+	require.NoError(t, err)
+
+	time.Sleep(time.Millisecond * 200)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	logchan, err := w.Stream(ctx, jobID, log.StreamOptions{Tail: 2, NoFollow: true})
+	require.NoError(t, err)
+	var records []log.Output
+	for record := range logchan {
+		records = append(records, record)
+	}
+	// the exit status isn't counted
+	require.Len(t, records, 3)
+	assert.Equal(t, "4\n", records[0].Data)
+	assert.Equal(t, "5\n", records[1].Data)
+	assert.NotNil(t, records[2].Exit)
+
+	// resumes after the offset of a record
+	logchan, err = w.Stream(ctx, jobID, log.StreamOptions{Offset: records[0].Offset, NoFollow: true})
+	require.NoError(t, err)
+	record := <-logchan
+	assert.Equal(t, "5\n", record.Data)
+	record = <-logchan
+	assert.NotNil(t, record.Exit)
+	_, ok := <-logchan
+	assert.False(t, ok)
+}
+
+func TestStreamFinished(t *testing.T) {
+	jobID, err := w.Start(Command{Name: "sh", Args: []string{"-c", "echo running; sleep 0.3; exit 3"}})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	// the stream of a running job ends once it's finished
+	logchan, err := w.Stream(ctx, jobID, log.StreamOptions{})
+	require.NoError(t, err)
+	var records []log.Output
+	for record := range logchan {
+		records = append(records, record)
+	}
+	require.NoError(t, ctx.Err())
+	require.Len(t, records, 2)
+	assert.Equal(t, "running\n", records[0].Data)
+	assert.Equal(t, &log.Exit{Code: 3, Exited: true}, records[1].Exit)
+
+	// and the stream of a finished job from past its end as well
+	logchan, err = w.Stream(ctx, jobID, log.StreamOptions{Offset: records[1].Offset + 100})
+	require.NoError(t, err)
+	record := <-logchan
+	assert.Equal(t, &log.Exit{Code: 3, Exited: true}, record.Exit)
+	_, ok := <-logchan
+	assert.False(t, ok)
+}
+
 func TestStreamNotExistingProcess(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-	logchan, err := w.Stream(ctx, "not-exists-job-id")
+	logchan, err := w.Stream(ctx, "not-exists-job-id", log.StreamOptions{})
 	cancel()
 	assert.Nil(t, logchan)
 	assert.Error(t, err)
 }
+
+func TestStartWithLimitsWithoutCgroup2(t *testing.T) {
+	config := conf.NewConfig()
+	config.CgroupFolder = t.TempDir()
+	w := newTestWorker(config)
+
+	_, err := w.Start(Command{Name: "ls", Limits: cgroup.Limits{MemoryMax: 1 << 20}})
+	assert.Error(t, err)
+}
+
+func TestStartWithLimits(t *testing.T) {
+	config := conf.NewConfig()
+	config.CgroupFolder = filepath.Join("/sys/fs/cgroup", "job-scheduler-test")
+	controllers, err := ioutil.ReadFile("/sys/fs/cgroup/cgroup.controllers")
+	if err != nil || !strings.Contains(string(controllers), "memory") {
+		t.Skip("cgroup v2 memory controller is not available")
+	}
+	w := newTestWorker(config)
+
+	jobID, err := w.Start(Command{Name: "sleep", Args: []string{"1"}, Limits: cgroup.Limits{MemoryMax: 64 << 20}})
+	require.NoError(t, err)
+	st, err := w.Query(jobID)
+	require.NoError(t, err)
+
+	path := filepath.Join(config.CgroupFolder, jobID)
+	procs, err := ioutil.ReadFile(filepath.Join(path, "cgroup.procs"))
+	require.NoError(t, err)
+	assert.Equal(t, strconv.Itoa(st.Pid), strings.TrimSpace(string(procs)))
+	memory, err := ioutil.ReadFile(filepath.Join(path, "memory.max"))
+	require.NoError(t, err)
+	assert.Equal(t, strconv.Itoa(64<<20), strings.TrimSpace(string(memory)))
+
+	time.Sleep(time.Second * 2)
+
+	assert.NoDirExists(t, path)
+}
+
+func TestStartIsolated(t *testing.T) {
+	jobID, err := w.Start(Command{
+		Name:      "sh",
+		Args:      []string{"-c", fmt.Sprintf("echo $PPID; hostname; test -d /proc/%d && echo visible || echo hidden", os.Getpid())},
+		Isolation: IsolationNamespaces,
+	})
+	require.NoError(t, err)
+
+	time.Sleep(time.Second)
+
+	st, err := w.Query(jobID)
+	require.NoError(t, err)
+	assert.True(t, st.Exited)
+	output, err := readOutput(w, jobID)
+	require.NoError(t, err)
+	// the shell is a child of the init process and the worker process is not visible
+	assert.Equal(t, []string{"1", jobID, "hidden"}, strings.Fields(output))
+}
+
+func TestStopIsolated(t *testing.T) {
+	jobID, err := w.Start(Command{Name: "sleep", Args: []string{"30"}, Isolation: IsolationNamespaces})
+	require.NoError(t, err)
+
+	time.Sleep(time.Millisecond * 200)
+
+	require.NoError(t, w.Stop(jobID, StopOptions{GracePeriod: time.Second * 5}))
+
+	time.Sleep(time.Millisecond * 500)
+
+	// the init process forwards the SIGTERM, the job doesn't wait for the SIGKILL
+	st, err := w.Query(jobID)
+	require.NoError(t, err)
+	assert.Equal(t, StateKilled, st.State)
+	assert.Equal(t, syscall.SIGTERM, st.Signal)
+	assert.Equal(t, TerminationStopped, st.Termination)
+}
+
+func TestStopProcessTree(t *testing.T) {
+	jobID, err := w.Start(Command{Name: "sh", Args: []string{"-c", "sleep 30 & echo $!; wait"}})
+	require.NoError(t, err)
+
+	time.Sleep(time.Millisecond * 500)
+
+	output, err := readOutput(w, jobID)
+	require.NoError(t, err)
+	child, err := strconv.Atoi(strings.TrimSpace(output))
+	require.NoError(t, err)
+
+	err = w.Stop(jobID, StopOptions{})
+	assert.NoError(t, err)
+
+	time.Sleep(time.Millisecond * 500)
+
+	assert.False(t, isAlive(child))
+}
+
+func TestStopEscalatesToKill(t *testing.T) {
+	jobID, err := w.Start(Command{Name: "sh", Args: []string{"-c", "trap '' TERM; while true; do sleep 0.1; done"}})
+	require.NoError(t, err)
+
+	time.Sleep(time.Millisecond * 200)
+
+	err = w.Stop(jobID, StopOptions{GracePeriod: time.Millisecond * 500})
+	assert.NoError(t, err)
+
+	time.Sleep(time.Millisecond * 200)
+
+	// the SIGTERM is ignored, the requested stop is reported meanwhile
+	st, err := w.Query(jobID)
+	assert.NoError(t, err)
+	assert.False(t, st.Exited)
+	assert.Equal(t, TerminationStopped, st.Termination)
+	assert.Equal(t, StateStopping, st.State)
+
+	time.Sleep(time.Second)
+
+	st, err = w.Query(jobID)
+	assert.NoError(t, err)
+	assert.Equal(t, TerminationKilled, st.Termination)
+	assert.Equal(t, -1, st.ExitCode)
+	assert.Equal(t, StateKilled, st.State)
+}
+
+func TestStopForce(t *testing.T) {
+	jobID, err := w.Start(Command{Name: "sleep", Args: []string{"2"}})
+	require.NoError(t, err)
+
+	err = w.Stop(jobID, StopOptions{Force: true})
+	assert.NoError(t, err)
+
+	time.Sleep(time.Millisecond * 200)
+
+	st, err := w.Query(jobID)
+	assert.NoError(t, err)
+	assert.Equal(t, TerminationKilled, st.Termination)
+}
+
+func TestSignal(t *testing.T) {
+	jobID, err := w.Start(Command{Name: "sh", Args: []string{"-c", "trap 'echo reload' HUP; while true; do sleep 0.1; done"}})
+	require.NoError(t, err)
+
+	time.Sleep(time.Millisecond * 200)
+
+	require.NoError(t, w.Signal(jobID, syscall.SIGHUP))
+	// the job is paused and resumed instead
+	assert.Error(t, w.Signal(jobID, syscall.SIGSTOP))
+
+	time.Sleep(time.Millisecond * 300)
+
+	// the trap writes to the stdout, the shell reports the hung up sleep on the stderr
+	output, err := readOutput(w, jobID, log.Stdout)
+	require.NoError(t, err)
+	assert.Equal(t, "reload\n", output)
+	st, err := w.Query(jobID)
+	require.NoError(t, err)
+	assert.Equal(t, StateRunning, st.State)
+
+	require.NoError(t, w.Signal(jobID, syscall.SIGKILL))
+
+	time.Sleep(time.Millisecond * 200)
+
+	st, err = w.Query(jobID)
+	require.NoError(t, err)
+	assert.Equal(t, StateKilled, st.State)
+	assert.Equal(t, syscall.SIGKILL, st.Signal)
+	assert.Equal(t, TerminationNone, st.Termination)
+	assert.Error(t, w.Signal(jobID, syscall.SIGHUP))
+}
+
+func TestParseSignal(t *testing.T) {
+	for _, name := range []string{"SIGUSR1", "usr1", "SigUsr1"} {
+		sig, err := ParseSignal(name)
+		require.NoError(t, err)
+		assert.Equal(t, syscall.SIGUSR1, sig)
+	}
+	_, err := ParseSignal("SIGNOPE")
+	assert.Error(t, err)
+}
+
+func TestPauseResume(t *testing.T) {
+	jobID, err := w.Start(Command{Name: "sh", Args: []string{"-c", "while true; do echo tick; sleep 0.05; done"}})
+	require.NoError(t, err)
+
+	time.Sleep(time.Millisecond * 200)
+
+	require.NoError(t, w.Pause(jobID))
+	assert.Error(t, w.Pause(jobID))
+	st, err := w.Query(jobID)
+	require.NoError(t, err)
+	assert.Equal(t, StatePaused, st.State)
+	assert.True(t, st.IsRunning())
+
+	// nothing is written while paused
+	time.Sleep(time.Millisecond * 100)
+	paused, err := readOutput(w, jobID)
+	require.NoError(t, err)
+	time.Sleep(time.Millisecond * 300)
+	output, err := readOutput(w, jobID)
+	require.NoError(t, err)
+	assert.Equal(t, paused, output)
+
+	require.NoError(t, w.Resume(jobID))
+	assert.Error(t, w.Resume(jobID))
+
+	time.Sleep(time.Millisecond * 300)
+
+	output, err = readOutput(w, jobID)
+	require.NoError(t, err)
+	assert.Greater(t, len(output), len(paused))
+	st, err = w.Query(jobID)
+	require.NoError(t, err)
+	assert.Equal(t, StateRunning, st.State)
+
+	require.NoError(t, w.Stop(jobID, StopOptions{Force: true}))
+}
+
+func TestStopPaused(t *testing.T) {
+	jobID, err := w.Start(Command{Name: "sleep", Args: []string{"5"}})
+	require.NoError(t, err)
+
+	time.Sleep(time.Millisecond * 100)
+
+	require.NoError(t, w.Pause(jobID))
+	// the paused job is resumed to handle the SIGTERM
+	require.NoError(t, w.Stop(jobID, StopOptions{}))
+
+	time.Sleep(time.Millisecond * 200)
+
+	st, err := w.Query(jobID)
+	require.NoError(t, err)
+	assert.Equal(t, StateKilled, st.State)
+	assert.Equal(t, syscall.SIGTERM, st.Signal)
+	assert.Error(t, w.Pause(jobID))
+	assert.Error(t, w.Resume(jobID))
+}
+
+// isAlive checks if a process is running, zombies are considered dead.
+func isAlive(pid int) bool {
+	stat, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return false
+	}
+	fields := strings.Fields(string(stat[strings.LastIndex(string(stat), ")")+1:]))
+	return len(fields) > 0 && fields[0] != "Z"
+}
+
+func TestReloadJobsFromStore(t *testing.T) {
+	config := conf.NewConfig()
+	config.LogFolder = t.TempDir()
+	config.JobStoreFile = filepath.Join(config.LogFolder, "jobs.journal")
+	before := newTestWorker(config)
+
+	finishedID, err := before.Start(Command{Name: "true"})
+	require.NoError(t, err)
+	runningID, err := before.Start(Command{Name: "sleep", Args: []string{"1"}})
+	require.NoError(t, err)
+
+	time.Sleep(time.Millisecond * 200)
+
+	// restarts the worker
+	after := newTestWorker(config)
+
+	st, err := after.Query(finishedID)
+	require.NoError(t, err)
+	assert.True(t, st.Exited)
+	assert.Zero(t, st.ExitCode)
+	assert.Equal(t, StateSucceeded, st.State)
+	// the running process is adopted
+	st, err = after.Query(runningID)
+	require.NoError(t, err)
+	assert.False(t, st.Exited)
+	assert.False(t, st.Lost)
+	assert.Equal(t, StateRunning, st.State)
+
+	time.Sleep(time.Second * 2)
+
+	st, err = after.Query(runningID)
+	require.NoError(t, err)
+	assert.True(t, st.Lost)
+	assert.Equal(t, -1, st.ExitCode)
+	assert.Equal(t, StateLost, st.State)
+}
+
+func TestReloadLostJob(t *testing.T) {
+	config := conf.NewConfig()
+	config.JobStoreFile = filepath.Join(t.TempDir(), "jobs.journal")
+	store, err := NewJournalStore(config.JobStoreFile)
+	require.NoError(t, err)
+	// a running job whose process is gone
+	err = store.Save(JobRecord{ID: "lost", Command: Command{Name: "sleep"}, Status: Status{Pid: os.Getpid()}, ProcessStart: 1})
+	require.NoError(t, err)
+	require.NoError(t, store.Close())
+
+	w := newTestWorker(config)
+
+	// the state of a job stored without state is derived from its status
+	st, err := w.Query("lost")
+	require.NoError(t, err)
+	assert.True(t, st.Lost)
+	assert.Equal(t, StateLost, st.State)
+	err = w.Stop("lost", StopOptions{})
+	assert.Error(t, err)
+}
+
+func TestReloadLostStoppedJob(t *testing.T) {
+	config := conf.NewConfig()
+	config.JobStoreFile = filepath.Join(t.TempDir(), "jobs.journal")
+	store, err := NewJournalStore(config.JobStoreFile)
+	require.NoError(t, err)
+	// a job stopped by the deadline whose process is gone
+	status := Status{State: StateRunning, Pid: os.Getpid(), Termination: TerminationStopped, TimedOut: true}
+	err = store.Save(JobRecord{ID: "stopped", Command: Command{Name: "sleep"}, Status: status, ProcessStart: 1})
+	require.NoError(t, err)
+	require.NoError(t, store.Close())
+
+	w := newTestWorker(config)
+
+	// the termination requested before the restart is kept
+	st, err := w.Query("stopped")
+	require.NoError(t, err)
+	assert.True(t, st.Lost)
+	assert.True(t, st.TimedOut)
+	assert.Equal(t, TerminationStopped, st.Termination)
+	assert.Equal(t, StateLost, st.State)
+}
+
+func TestList(t *testing.T) {
+	w := newTestWorker(conf.NewConfig())
+	okID, err := w.Start(Command{Name: "true"})
+	require.NoError(t, err)
+	failedID, err := w.Start(Command{Name: "false"})
+	require.NoError(t, err)
+	runningID, err := w.Start(Command{Name: "sleep", Args: []string{"1"}})
+	require.NoError(t, err)
+
+	time.Sleep(time.Millisecond * 200)
+
+	ids := func(jobs []JobSummary) []string {
+		var ids []string
+		for _, job := range jobs {
+			ids = append(ids, job.ID)
+		}
+		return ids
+	}
+	jobs, next, err := w.List(ListOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{okID, failedID, runningID}, ids(jobs))
+	assert.Empty(t, next)
+
+	jobs, _, err = w.List(ListOptions{State: StateFilterRunning})
+	require.NoError(t, err)
+	assert.Equal(t, []string{runningID}, ids(jobs))
+	jobs, _, err = w.List(ListOptions{State: StateFilterExited})
+	require.NoError(t, err)
+	assert.Equal(t, []string{okID}, ids(jobs))
+	jobs, _, err = w.List(ListOptions{State: StateFilterFailed})
+	require.NoError(t, err)
+	assert.Equal(t, []string{failedID}, ids(jobs))
+	jobs, _, err = w.List(ListOptions{Name: "sleep"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{runningID}, ids(jobs))
+	jobs, _, err = w.List(ListOptions{StartedAfter: time.Now()})
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+
+	jobs, next, err = w.List(ListOptions{PageSize: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []string{okID, failedID}, ids(jobs))
+	require.NotEmpty(t, next)
+	jobs, next, err = w.List(ListOptions{PageSize: 2, PageToken: next})
+	require.NoError(t, err)
+	assert.Equal(t, []string{runningID}, ids(jobs))
+	assert.Empty(t, next)
+
+	_, _, err = w.List(ListOptions{PageToken: "invalid"})
+	assert.Error(t, err)
+}
+
+func TestListQueuedStarted(t *testing.T) {
+	config := conf.NewConfig()
+	config.MaxConcurrentJobs = 1
+	w := newTestWorker(config)
+	runningID, err := w.Start(Command{Name: "sleep", Args: []string{"0.2"}})
+	require.NoError(t, err)
+	queuedID, err := w.Start(Command{Name: "true"})
+	require.NoError(t, err)
+	lastID, err := w.Start(Command{Name: "true"})
+	require.NoError(t, err)
+
+	jobs, next, err := w.List(ListOptions{PageSize: 2})
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+	assert.Equal(t, runningID, jobs[0].ID)
+	assert.Equal(t, queuedID, jobs[1].ID)
+
+	// the queued jobs start after the first page, their position is kept
+	waitFinished(t, w, lastID)
+
+	jobs, next, err = w.List(ListOptions{PageSize: 2, PageToken: next})
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, lastID, jobs[0].ID)
+	assert.Empty(t, next)
+}
+
+func TestJanitorIntervalDefault(t *testing.T) {
+	// a configuration literal doesn't set the janitor interval
+	wk, err := NewWorker(conf.Config{LogFolder: t.TempDir(), LogRetention: time.Hour})
+	require.NoError(t, err)
+
+	// the janitor is running, with the default interval
+	time.Sleep(time.Millisecond * 100)
+
+	assert.Equal(t, defaultJanitorInterval, wk.(*worker).config.LogJanitorInterval)
+}
+
+func TestStopGracePeriodDefault(t *testing.T) {
+	// a configuration literal doesn't set the grace period
+	wk, err := NewWorker(conf.Config{LogFolder: t.TempDir()})
+	require.NoError(t, err)
+	assert.Equal(t, defaultStopGracePeriod, wk.(*worker).config.StopGracePeriod)
+
+	jobID, err := wk.Start(Command{Name: "/bin/sleep", Args: []string{"5"}})
+	require.NoError(t, err)
+	require.NoError(t, wk.Stop(jobID, StopOptions{}))
+
+	// the job is stopped by the SIGTERM, not killed at once
+	st := waitFinished(t, wk, jobID)
+	assert.Equal(t, syscall.SIGTERM, st.Signal)
+	assert.Equal(t, TerminationStopped, st.Termination)
+}
+
+func TestStartTimeout(t *testing.T) {
+	jobID, err := w.Start(Command{Name: "sleep", Args: []string{"5"}, Timeout: time.Millisecond * 300})
+	require.NoError(t, err)
+
+	st, err := w.Query(jobID)
+	require.NoError(t, err)
+	assert.False(t, st.TimedOut)
+
+	time.Sleep(time.Millisecond * 600)
+
+	st, err = w.Query(jobID)
+	require.NoError(t, err)
+	assert.True(t, st.TimedOut)
+	assert.Equal(t, TerminationStopped, st.Termination)
+	assert.Equal(t, -1, st.ExitCode)
+	assert.Equal(t, StateTimedOut, st.State)
+}
+
+func TestStartDeadline(t *testing.T) {
+	_, err := w.Start(Command{Name: "sleep", Args: []string{"5"}, Deadline: time.Now().Add(-time.Second)})
+	assert.Error(t, err)
+
+	jobID, err := w.Start(Command{Name: "sleep", Args: []string{"5"}, Deadline: time.Now().Add(time.Millisecond * 300)})
+	require.NoError(t, err)
+
+	time.Sleep(time.Millisecond * 600)
+
+	st, err := w.Query(jobID)
+	require.NoError(t, err)
+	assert.True(t, st.TimedOut)
+}
+
+func TestStartQueuedDeadline(t *testing.T) {
+	config := conf.NewConfig()
+	config.MaxConcurrentJobs = 1
+	w := newTestWorker(config)
+	runningID, err := w.Start(Command{Name: "sleep", Args: []string{"5"}})
+	require.NoError(t, err)
+	defer w.Stop(runningID, StopOptions{Force: true})
+	queuedID, err := w.Start(Command{Name: "sleep", Args: []string{"5"}, Deadline: time.Now().Add(time.Millisecond * 300)})
+	require.NoError(t, err)
+	otherID, err := w.Start(Command{Name: "sleep", Args: []string{"5"}})
+	require.NoError(t, err)
+
+	// the queued job expires while the slot is still taken
+	time.Sleep(time.Millisecond * 600)
+
+	st, err := w.Query(queuedID)
+	require.NoError(t, err)
+	assert.False(t, st.Queued)
+	assert.False(t, st.IsRunning())
+	assert.True(t, st.TimedOut)
+	assert.Equal(t, StateTimedOut, st.State)
+	st, err = w.Query(otherID)
+	require.NoError(t, err)
+	assert.True(t, st.Queued)
+	assert.Equal(t, 1, st.QueuePosition)
+	require.NoError(t, w.Stop(otherID, StopOptions{}))
+}
+
+func TestStartRetry(t *testing.T) {
+	retry := RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond * 50, ExitCodes: []int{3}}
+	jobID, err := w.Start(Command{Name: "sh", Args: []string{"-c", "echo attempt; exit 3"}, Retry: retry})
+	require.NoError(t, err)
+
+	st := waitFinished(t, w, jobID)
+	assert.True(t, st.Exited)
+	assert.Equal(t, 3, st.ExitCode)
+	assert.Equal(t, StateFailed, st.State)
+	assert.Equal(t, 3, st.Attempt)
+	require.Len(t, st.Attempts, 3)
+	for _, attempt := range st.Attempts {
+		assert.Equal(t, 3, attempt.ExitCode)
+		assert.False(t, attempt.EndTime.Before(attempt.StartTime))
+	}
+	output, err := readOutput(w, jobID)
+	require.NoError(t, err)
+	assert.Equal(t, "attempt\nattempt\nattempt\n", output)
+}
+
+func TestStartRetryNotRetryableExitCode(t *testing.T) {
+	retry := RetryPolicy{MaxAttempts: 3, ExitCodes: []int{3}}
+	jobID, err := w.Start(Command{Name: "sh", Args: []string{"-c", "exit 4"}, Retry: retry})
+	require.NoError(t, err)
+
+	time.Sleep(time.Millisecond * 200)
+
+	st, err := w.Query(jobID)
+	require.NoError(t, err)
+	assert.Equal(t, 4, st.ExitCode)
+	assert.Equal(t, 1, st.Attempt)
+	assert.Len(t, st.Attempts, 1)
+}
+
+func TestStopWhileRetrying(t *testing.T) {
+	retry := RetryPolicy{MaxAttempts: 3, Backoff: time.Second * 5}
+	jobID, err := w.Start(Command{Name: "false", Retry: retry})
+	require.NoError(t, err)
+
+	time.Sleep(time.Millisecond * 200)
+
+	st, err := w.Query(jobID)
+	require.NoError(t, err)
+	assert.True(t, st.IsRunning())
+	assert.Equal(t, StatePending, st.State)
+	assert.Len(t, st.Attempts, 1)
+
+	require.NoError(t, w.Stop(jobID, StopOptions{}))
+	st, err = w.Query(jobID)
+	require.NoError(t, err)
+	assert.False(t, st.IsRunning())
+	assert.Equal(t, StateKilled, st.State)
+	assert.Equal(t, TerminationStopped, st.Termination)
+	assert.Equal(t, 1, st.ExitCode)
+	assert.Equal(t, 1, st.Attempt)
+}
+
+func TestStateTransitions(t *testing.T) {
+	job := &Job{ID: "job", Status: &Status{State: StatePending}}
+	require.NoError(t, job.transition(StateRunning))
+	require.NoError(t, job.transition(StateStopping))
+	// a stopping job doesn't start again
+	assert.Error(t, job.transition(StateRunning))
+	assert.Equal(t, StateStopping, job.Status.State)
+	require.NoError(t, job.transition(StateKilled))
+	// the final states are kept
+	for _, state := range []State{StatePending, StateRunning, StateSucceeded, StateLost} {
+		assert.Error(t, job.transition(state))
+	}
+	assert.Equal(t, StateKilled, job.Status.State)
+
+	assert.Equal(t, StateSucceeded, finalState(Status{Exited: true}))
+	assert.Equal(t, StateFailed, finalState(Status{Exited: true, ExitCode: 2}))
+	assert.Equal(t, StateKilled, finalState(Status{ExitCode: -1, Signal: syscall.SIGSEGV}))
+	assert.Equal(t, StateKilled, finalState(Status{Exited: true, Termination: TerminationStopped}))
+	assert.Equal(t, StateTimedOut, finalState(Status{ExitCode: -1, Termination: TerminationStopped, TimedOut: true}))
+	assert.Equal(t, "timed_out", StateTimedOut.String())
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := RetryPolicy{Backoff: time.Millisecond * 100, MaxBackoff: time.Millisecond * 300}
+	assert.Equal(t, time.Millisecond*100, policy.delay(1))
+	assert.Equal(t, time.Millisecond*200, policy.delay(2))
+	assert.Equal(t, time.Millisecond*300, policy.delay(3))
+	assert.Equal(t, time.Millisecond*300, policy.delay(100))
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		delay := policy.delay(1)
+		assert.True(t, delay > time.Millisecond*50 && delay <= time.Millisecond*100, delay)
+	}
+	assert.Error(t, RetryPolicy{Jitter: 2}.validate())
+}
+
+func TestStartQueue(t *testing.T) {
+	config := conf.NewConfig()
+	config.MaxConcurrentJobs = 1
+	w := newTestWorker(config)
+	runningID, err := w.Start(Command{Name: "sleep", Args: []string{"0.3"}})
+	require.NoError(t, err)
+	lowID, err := w.Start(Command{Name: "sleep", Args: []string{"0.1"}})
+	require.NoError(t, err)
+	cancelledID, err := w.Start(Command{Name: "sleep", Args: []string{"0.1"}})
+	require.NoError(t, err)
+	highID, err := w.Start(Command{Name: "sleep", Args: []string{"0.1"}, Priority: 5})
+	require.NoError(t, err)
+
+	st, err := w.Query(runningID)
+	require.NoError(t, err)
+	assert.False(t, st.Queued)
+	for position, jobID := range []string{highID, lowID, cancelledID} {
+		st, err := w.Query(jobID)
+		require.NoError(t, err)
+		assert.True(t, st.Queued)
+		assert.True(t, st.IsRunning())
+		assert.Equal(t, StatePending, st.State)
+		assert.Equal(t, position+1, st.QueuePosition)
+	}
+
+	require.NoError(t, w.Stop(cancelledID, StopOptions{}))
+	st, err = w.Query(cancelledID)
+	require.NoError(t, err)
+	assert.False(t, st.Queued)
+	assert.False(t, st.IsRunning())
+	assert.Equal(t, TerminationStopped, st.Termination)
+	st, err = w.Query(lowID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, st.QueuePosition)
+
+	time.Sleep(time.Millisecond * 900)
+
+	high, err := w.Query(highID)
+	require.NoError(t, err)
+	low, err := w.Query(lowID)
+	require.NoError(t, err)
+	assert.True(t, high.Exited)
+	assert.True(t, low.Exited)
+	assert.Equal(t, StateSucceeded, high.State)
+	assert.True(t, high.StartTime.Before(low.StartTime))
+}
+
+func TestStartEnvAndDir(t *testing.T) {
+	dir := t.TempDir()
+	jobID, err := w.Start(Command{Name: "sh", Args: []string{"-c", "echo $JOB_VAR; pwd"}, Env: []string{"JOB_VAR=value"}, Dir: dir})
+	require.NoError(t, err)
+
+	time.Sleep(time.Millisecond * 200)
+
+	output, err := readOutput(w, jobID)
+	require.NoError(t, err)
+	assert.Equal(t, "value\n"+dir+"\n", output)
+
+	_, err = w.Start(Command{Name: "pwd", Dir: filepath.Join(dir, "notexists")})
+	assert.Error(t, err)
+	_, err = w.Start(Command{Name: "env", Env: []string{"INVALID"}})
+	assert.Error(t, err)
+}
+
+func TestStartLookPath(t *testing.T) {
+	dir := t.TempDir()
+	bin := filepath.Join(dir, "bin")
+	require.NoError(t, os.Mkdir(bin, 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "run.sh"), []byte("#!/bin/sh\necho run\n"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(bin, "jobtool"), []byte("#!/bin/sh\necho tool\n"), 0755))
+
+	// a relative name is relative to the job working directory
+	runID, err := w.Start(Command{Name: "./run.sh", Dir: dir})
+	require.NoError(t, err)
+	// a bare name is searched in the job PATH
+	toolID, err := w.Start(Command{Name: "jobtool", Env: []string{"PATH=" + bin + ":/usr/bin:/bin"}})
+	require.NoError(t, err)
+	_, err = w.Start(Command{Name: "jobtool"})
+	assert.Error(t, err)
+	_, err = w.Start(Command{Name: "./run.sh"})
+	assert.Error(t, err)
+
+	time.Sleep(time.Millisecond * 200)
+
+	output, err := readOutput(w, runID)
+	require.NoError(t, err)
+	assert.Equal(t, "run\n", output)
+	output, err = readOutput(w, toolID)
+	require.NoError(t, err)
+	assert.Equal(t, "tool\n", output)
+}
+
+func TestStartUser(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("switching the user requires root")
+	}
+	jobID, err := w.Start(Command{Name: "id", Args: []string{"-u"}, User: "65534:65534"})
+	require.NoError(t, err)
+
+	time.Sleep(time.Millisecond * 200)
+
+	output, err := readOutput(w, jobID)
+	require.NoError(t, err)
+	assert.Equal(t, "65534\n", output)
+
+	_, err = w.Start(Command{Name: "id", User: "notexists"})
+	assert.Error(t, err)
+}
+
+func TestStartEnvNotInherited(t *testing.T) {
+	os.Setenv("WORKER_SECRET", "secret")
+	defer os.Unsetenv("WORKER_SECRET")
+	jobID, err := w.Start(Command{Name: "env", Env: []string{"JOB_VAR=value"}})
+	require.NoError(t, err)
+
+	time.Sleep(time.Millisecond * 200)
+
+	output, err := readOutput(w, jobID)
+	require.NoError(t, err)
+	env := strings.Split(strings.TrimSpace(output), "\n")
+	assert.NotContains(t, env, "WORKER_SECRET=secret")
+	assert.Contains(t, env, "JOB_VAR=value")
+	assert.Contains(t, env, "PATH="+os.Getenv("PATH"))
+}
+
+func TestStartEnvOverridesAllowed(t *testing.T) {
+	jobID, err := w.Start(Command{Name: "env", Env: []string{"PATH=/bin"}})
+	require.NoError(t, err)
+
+	time.Sleep(time.Millisecond * 200)
+
+	output, err := readOutput(w, jobID)
+	require.NoError(t, err)
+	env := strings.Split(strings.TrimSpace(output), "\n")
+	assert.Contains(t, env, "PATH=/bin")
+	assert.NotContains(t, env, "PATH="+os.Getenv("PATH"))
+}
+
+func TestStartEnvInherited(t *testing.T) {
+	os.Setenv("WORKER_SECRET", "secret")
+	defer os.Unsetenv("WORKER_SECRET")
+	config := conf.NewConfig()
+	config.InheritEnv = true
+	w := newTestWorker(config)
+	jobID, err := w.Start(Command{Name: "env"})
+	require.NoError(t, err)
+
+	time.Sleep(time.Millisecond * 200)
+
+	output, err := readOutput(w, jobID)
+	require.NoError(t, err)
+	assert.Contains(t, strings.Split(output, "\n"), "WORKER_SECRET=secret")
+}
+
+func TestAttach(t *testing.T) {
+	jobID, err := w.Start(Command{Name: "cat", Interactive: true})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stdin, logchan, err := w.Attach(ctx, jobID)
+	require.NoError(t, err)
+	_, err = stdin.Write([]byte("hello\n"))
+	require.NoError(t, err)
+	select {
+	case output := <-logchan:
+		assert.Equal(t, log.Stdout, output.Source)
+		assert.Equal(t, "hello\n", output.Data)
+	case <-time.After(time.Second):
+		t.Fatal("the output wasn't streamed")
+	}
+
+	require.NoError(t, stdin.Close())
+	time.Sleep(time.Millisecond * 200)
+	st, err := w.Query(jobID)
+	require.NoError(t, err)
+	assert.True(t, st.Exited)
+	assert.Equal(t, 0, st.ExitCode)
+
+	_, _, err = w.Attach(ctx, jobID)
+	assert.Error(t, err)
+}
+
+func TestAttachNotInteractive(t *testing.T) {
+	jobID, err := w.Start(Command{Name: "sleep", Args: []string{"1"}})
+	require.NoError(t, err)
+
+	_, _, err = w.Attach(context.Background(), jobID)
+	assert.Error(t, err)
+}
+
+func TestStartTTY(t *testing.T) {
+	if _, err := os.Stat("/dev/ptmx"); err != nil {
+		t.Skip("pseudo-terminals aren't available")
+	}
+	jobID, err := w.Start(Command{Name: "sh", Args: []string{"-c", "[ -t 0 ] && [ -t 1 ] && echo terminal"}, TTY: true})
+	require.NoError(t, err)
+
+	time.Sleep(time.Millisecond * 200)
+
+	st, err := w.Query(jobID)
+	require.NoError(t, err)
+	assert.True(t, st.Exited)
+	assert.Equal(t, 0, st.ExitCode)
+	output, err := readOutput(w, jobID)
+	require.NoError(t, err)
+	// the terminal translates the new lines
+	assert.Equal(t, "terminal\r\n", output)
+}
+
+func TestResize(t *testing.T) {
+	if _, err := os.Stat("/dev/ptmx"); err != nil {
+		t.Skip("pseudo-terminals aren't available")
+	}
+	jobID, err := w.Start(Command{Name: "sh", Args: []string{"-c", "read line; stty size"}, Interactive: true, TTY: true})
+	require.NoError(t, err)
+
+	time.Sleep(time.Millisecond * 100)
+	require.NoError(t, w.Resize(jobID, WindowSize{Rows: 40, Cols: 120}))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stdin, _, err := w.Attach(ctx, jobID)
+	require.NoError(t, err)
+	_, err = stdin.Write([]byte("\n"))
+	require.NoError(t, err)
+
+	time.Sleep(time.Millisecond * 200)
+
+	output, err := readOutput(w, jobID)
+	require.NoError(t, err)
+	assert.Contains(t, output, "40 120\r\n")
+
+	notty, err := w.Start(Command{Name: "sleep", Args: []string{"1"}})
+	require.NoError(t, err)
+	assert.Error(t, w.Resize(notty, WindowSize{Rows: 40, Cols: 120}))
+}
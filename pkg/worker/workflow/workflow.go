@@ -0,0 +1,531 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	logger "log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/renatoaguimaraes/job-scheduler/pkg/worker"
+)
+
+// pollInterval interval to check if the running steps are finished.
+const pollInterval = time.Millisecond * 100
+
+// Condition of a step dependency.
+type Condition int
+
+const (
+	// ConditionSuccess runs the step if the dependency succeeded.
+	ConditionSuccess Condition = iota
+	// ConditionFailure runs the step if the dependency failed.
+	ConditionFailure
+	// ConditionAlways runs the step whatever the dependency outcome.
+	ConditionAlways
+)
+
+// Dependency of a step on another step.
+type Dependency struct {
+	// Step name
+	Step string
+	// Condition of the dependency outcome to run the step
+	Condition Condition
+}
+
+// Step of a workflow.
+type Step struct {
+	// Name unique in the workflow
+	Name string
+	// Command started when the dependencies are satisfied
+	Command worker.Command
+	// After dependencies of the step, the step is started when all
+	// dependencies are finished with the expected outcome, and
+	// skipped if any of them isn't
+	After []Dependency
+}
+
+// Spec of a workflow, a directed acyclic graph of steps.
+type Spec struct {
+	// Name of the workflow
+	Name string
+	// Steps of the workflow
+	Steps []Step
+}
+
+// StepState of a step.
+type StepState int
+
+const (
+	// StepPending the step is waiting for its dependencies.
+	StepPending StepState = iota
+	// StepRunning the step Job is running.
+	StepRunning
+	// StepSucceeded the step Job exited with code 0.
+	StepSucceeded
+	// StepFailed the step Job failed, or couldn't be started.
+	StepFailed
+	// StepSkipped a dependency condition wasn't satisfied.
+	StepSkipped
+	// StepCancelled the workflow was stopped before the step has finished.
+	StepCancelled
+)
+
+// finished checks if the step state is final.
+func (s StepState) finished() bool {
+	return s != StepPending && s != StepRunning
+}
+
+// State of a workflow.
+type State int
+
+const (
+	// StateRunning some step is pending or running.
+	StateRunning State = iota
+	// StateSucceeded all steps are finished, and none has failed.
+	StateSucceeded
+	// StateFailed all steps are finished, and some has failed.
+	StateFailed
+	// StateCancelled the workflow was stopped.
+	StateCancelled
+)
+
+// StepStatus of a workflow step.
+type StepStatus struct {
+	// Name of the step
+	Name string
+	// State of the step
+	State StepState
+	// JobID of the step Job, empty if not started
+	JobID string
+	// Status of the step Job, when last checked
+	Status worker.Status
+	// Error which kept the step Job from starting
+	Error string
+}
+
+// Workflow is a snapshot of a created workflow.
+type Workflow struct {
+	// ID workflow identifier
+	ID string
+	// Spec of the workflow
+	Spec Spec
+	// State aggregated from the step states
+	State State
+	// Steps status in the spec order
+	Steps []StepStatus
+}
+
+// Engine defines the operations to manage workflows.
+type Engine interface {
+	// Create validates and starts a workflow.
+	//    - spec: steps and their dependencies
+	// It returns the workflow ID and the execution error encountered.
+	Create(spec Spec) (workflowID string, err error)
+	// Stop cancels the pending steps, and stops the running ones.
+	//    - ID: workflow identifier
+	// It returns the execution error encountered.
+	Stop(workflowID string) (err error)
+	// Query a workflow to check the current status.
+	//    - ID: workflow identifier
+	// It returns the workflow snapshot and the execution error encountered.
+	Query(workflowID string) (workflow Workflow, err error)
+	// List returns the workflows ordered by ID.
+	List() (workflows []Workflow)
+	// Close stops watching the workflows, the running Jobs keep running.
+	Close()
+}
+
+// NewEngine creates a new Engine which starts the step Jobs on the given Worker.
+func NewEngine(w worker.Worker) Engine {
+	return &engine{
+		worker:    w,
+		workflows: make(map[string]*workflow),
+	}
+}
+
+// engine implementation.
+type engine struct {
+	// worker starts the jobs
+	worker worker.Worker
+	// workflows by identifier
+	workflows map[string]*workflow
+	// mtx to control workflows concurrent access
+	mtx sync.Mutex
+}
+
+// workflow is a created workflow.
+type workflow struct {
+	id    string
+	spec  Spec
+	state State
+	steps []*StepStatus
+	// stop is closed when the workflow is stopped or finished
+	stop chan struct{}
+}
+
+// Create validates the graph and launches a goroutine which starts
+// each step when its dependencies are satisfied.
+func (e *engine) Create(spec Spec) (string, error) {
+	if err := validate(spec); err != nil {
+		return "", err
+	}
+	wf := &workflow{
+		id:   uuid.NewString(),
+		spec: spec,
+		stop: make(chan struct{}),
+	}
+	for _, step := range spec.Steps {
+		wf.steps = append(wf.steps, &StepStatus{Name: step.Name})
+	}
+	e.mtx.Lock()
+	e.workflows[wf.id] = wf
+	e.mtx.Unlock()
+	e.advance(wf)
+	go e.run(wf)
+	return wf.id, nil
+}
+
+// validate checks the step names, the dependencies and that there is no cycle.
+func validate(spec Spec) error {
+	if len(spec.Steps) == 0 {
+		return errors.New("you must pass at least one step")
+	}
+	steps := make(map[string]Step)
+	for _, step := range spec.Steps {
+		if step.Name == "" {
+			return errors.New("you must pass a step name")
+		}
+		if _, ok := steps[step.Name]; ok {
+			return fmt.Errorf("duplicated step %q", step.Name)
+		}
+		if step.Command.Name == "" {
+			return fmt.Errorf("you must pass a program name to the step %q", step.Name)
+		}
+		steps[step.Name] = step
+	}
+	for _, step := range spec.Steps {
+		for _, dep := range step.After {
+			if _, ok := steps[dep.Step]; !ok {
+				return fmt.Errorf("step %q depends on the unknown step %q", step.Name, dep.Step)
+			}
+		}
+	}
+	// depth-first search, a step visited again while in the path closes a cycle
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	marks := make(map[string]int)
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch marks[name] {
+		case visiting:
+			return fmt.Errorf("the step %q depends on itself", name)
+		case visited:
+			return nil
+		}
+		marks[name] = visiting
+		for _, dep := range steps[name].After {
+			if err := visit(dep.Step); err != nil {
+				return err
+			}
+		}
+		marks[name] = visited
+		return nil
+	}
+	for _, step := range spec.Steps {
+		if err := visit(step.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Stop cancels the pending steps and stops the running step Jobs. The worker
+// is called without holding the lock.
+func (e *engine) Stop(workflowID string) error {
+	jobIDs, err := e.cancel(workflowID)
+	if err != nil {
+		return err
+	}
+	for _, jobID := range jobIDs {
+		e.stopJob(workflowID, jobID)
+	}
+	return nil
+}
+
+// cancel cancels the pending and running steps of a workflow.
+// It returns the running step Jobs.
+func (e *engine) cancel(workflowID string) ([]string, error) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	wf, err := e.getWorkflow(workflowID)
+	if err != nil {
+		return nil, err
+	}
+	if wf.state != StateRunning {
+		return nil, errors.New("the workflow is already finished")
+	}
+	var jobIDs []string
+	for _, step := range wf.steps {
+		switch step.State {
+		case StepRunning:
+			jobIDs = append(jobIDs, step.JobID)
+			step.State = StepCancelled
+		case StepPending:
+			step.State = StepCancelled
+		}
+	}
+	wf.state = StateCancelled
+	close(wf.stop)
+	return jobIDs, nil
+}
+
+// stopJob stops a step Job.
+func (e *engine) stopJob(workflowID, jobID string) {
+	if err := e.worker.Stop(jobID, worker.StopOptions{}); err != nil {
+		logger.Printf("Workflow %v fails to stop the job %v, %v", workflowID, jobID, err)
+	}
+}
+
+// Query returns a snapshot of a workflow.
+func (e *engine) Query(workflowID string) (Workflow, error) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	wf, err := e.getWorkflow(workflowID)
+	if err != nil {
+		return Workflow{}, err
+	}
+	return wf.snapshot(), nil
+}
+
+// List returns a snapshot of the workflows.
+func (e *engine) List() []Workflow {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	workflows := make([]Workflow, 0, len(e.workflows))
+	for _, wf := range e.workflows {
+		workflows = append(workflows, wf.snapshot())
+	}
+	sort.Slice(workflows, func(i, j int) bool {
+		return workflows[i].ID < workflows[j].ID
+	})
+	return workflows
+}
+
+// Close stops watching the running workflows.
+func (e *engine) Close() {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	for _, wf := range e.workflows {
+		if wf.state == StateRunning {
+			wf.state = StateCancelled
+			close(wf.stop)
+		}
+	}
+}
+
+// run checks the running steps until the workflow is finished or stopped.
+func (e *engine) run(wf *workflow) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			e.advance(wf)
+		case <-wf.stop:
+			return
+		}
+	}
+}
+
+// advance updates the running steps, starts or skips the pending steps whose
+// dependencies are finished, and aggregates the workflow state. The worker is
+// called without holding the lock, the advances of a workflow are sequential.
+func (e *engine) advance(wf *workflow) {
+	e.mtx.Lock()
+	running := wf.running()
+	e.mtx.Unlock()
+	statuses := make(map[string]worker.Status)
+	for _, jobID := range running {
+		status, err := e.worker.Query(jobID)
+		if err != nil {
+			logger.Printf("Workflow %v fails to query the job %v, %v", wf.id, jobID, err)
+			continue
+		}
+		statuses[jobID] = status
+	}
+	// jobs started for steps cancelled meanwhile
+	var cancelled []string
+	e.mtx.Lock()
+	wf.update(statuses)
+	// a finished step may unblock its dependents, so repeat until nothing is started
+	for ready := wf.ready(); len(ready) > 0; ready = wf.ready() {
+		e.mtx.Unlock()
+		jobIDs := make([]string, len(ready))
+		errs := make([]error, len(ready))
+		for i, index := range ready {
+			jobIDs[i], errs[i] = e.worker.Start(wf.spec.Steps[index].Command)
+		}
+		e.mtx.Lock()
+		for i, index := range ready {
+			step := wf.steps[index]
+			switch {
+			case errs[i] != nil:
+				logger.Printf("Workflow %v fails to start the step %v, %v", wf.id, step.Name, errs[i])
+				if step.State == StepPending {
+					step.State = StepFailed
+					step.Error = errs[i].Error()
+				}
+			case step.State == StepCancelled:
+				step.JobID = jobIDs[i]
+				cancelled = append(cancelled, jobIDs[i])
+			default:
+				step.JobID = jobIDs[i]
+				step.State = StepRunning
+			}
+		}
+	}
+	wf.aggregate()
+	e.mtx.Unlock()
+	for _, jobID := range cancelled {
+		e.stopJob(wf.id, jobID)
+	}
+}
+
+// running returns the Jobs of the running steps. It must be called holding the lock.
+func (wf *workflow) running() []string {
+	var jobIDs []string
+	if wf.state != StateRunning {
+		return jobIDs
+	}
+	for _, step := range wf.steps {
+		if step.State == StepRunning {
+			jobIDs = append(jobIDs, step.JobID)
+		}
+	}
+	return jobIDs
+}
+
+// update sets the status of the running steps from their Jobs status.
+// It must be called holding the lock.
+func (wf *workflow) update(statuses map[string]worker.Status) {
+	if wf.state != StateRunning {
+		return
+	}
+	for _, step := range wf.steps {
+		if step.State != StepRunning {
+			continue
+		}
+		status, ok := statuses[step.JobID]
+		if !ok {
+			continue
+		}
+		step.Status = status
+		if status.IsRunning() {
+			continue
+		}
+		if status.State == worker.StateSucceeded {
+			step.State = StepSucceeded
+		} else {
+			step.State = StepFailed
+		}
+	}
+}
+
+// ready skips the pending steps whose dependencies are not satisfied, and
+// returns the index of the pending steps to start. It must be called holding
+// the lock.
+func (wf *workflow) ready() []int {
+	var ready []int
+	if wf.state != StateRunning {
+		return ready
+	}
+	steps := make(map[string]*StepStatus)
+	for _, step := range wf.steps {
+		steps[step.Name] = step
+	}
+	// a skipped step may unblock its dependents, so repeat until nothing changes
+	for changed := true; changed; {
+		changed = false
+		for i, step := range wf.steps {
+			if step.State != StepPending {
+				continue
+			}
+			finished, satisfied := dependencies(wf.spec.Steps[i], steps)
+			if finished && !satisfied {
+				step.State = StepSkipped
+				changed = true
+			}
+		}
+	}
+	for i, step := range wf.steps {
+		if step.State != StepPending {
+			continue
+		}
+		if finished, _ := dependencies(wf.spec.Steps[i], steps); finished {
+			ready = append(ready, i)
+		}
+	}
+	return ready
+}
+
+// aggregate finishes the workflow when all the steps are finished.
+// It must be called holding the lock.
+func (wf *workflow) aggregate() {
+	if wf.state != StateRunning {
+		return
+	}
+	state := StateSucceeded
+	for _, step := range wf.steps {
+		if !step.State.finished() {
+			return
+		}
+		if step.State == StepFailed {
+			state = StateFailed
+		}
+	}
+	wf.state = state
+	close(wf.stop)
+}
+
+// dependencies checks if the dependencies of a step are finished, and
+// if so, whether all of their conditions are satisfied.
+func dependencies(step Step, steps map[string]*StepStatus) (ready bool, satisfied bool) {
+	satisfied = true
+	for _, dep := range step.After {
+		state := steps[dep.Step].State
+		if !state.finished() {
+			return false, false
+		}
+		switch dep.Condition {
+		case ConditionSuccess:
+			satisfied = satisfied && state == StepSucceeded
+		case ConditionFailure:
+			satisfied = satisfied && state == StepFailed
+		}
+	}
+	return true, satisfied
+}
+
+// snapshot returns a copy of the workflow. It must be called holding the lock.
+func (wf *workflow) snapshot() Workflow {
+	workflow := Workflow{ID: wf.id, Spec: wf.spec, State: wf.state}
+	for _, step := range wf.steps {
+		workflow.Steps = append(workflow.Steps, *step)
+	}
+	return workflow
+}
+
+// getWorkflow helper to get a workflow given an id.
+func (e *engine) getWorkflow(workflowID string) (*workflow, error) {
+	wf, ok := e.workflows[workflowID]
+	if !ok {
+		return nil, fmt.Errorf("Workflow %v not found", workflowID)
+	}
+	return wf, nil
+}
@@ -0,0 +1,238 @@
+package workflow
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/renatoaguimaraes/job-scheduler/pkg/worker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeWorker finishes the true and false jobs immediately,
+// and keeps the other jobs running until stopped.
+type fakeWorker struct {
+	worker.Worker
+	mtx     sync.Mutex
+	started []string
+	names   map[string]string
+	stopped map[string]bool
+	// delay of the job starts
+	delay time.Duration
+}
+
+func newFakeWorker() *fakeWorker {
+	return &fakeWorker{names: make(map[string]string), stopped: make(map[string]bool)}
+}
+
+func (w *fakeWorker) Start(command worker.Command) (string, error) {
+	w.mtx.Lock()
+	delay := w.delay
+	w.mtx.Unlock()
+	time.Sleep(delay)
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	jobID := fmt.Sprintf("job-%d", len(w.started))
+	w.started = append(w.started, command.Name)
+	w.names[jobID] = command.Name
+	return jobID, nil
+}
+
+func (w *fakeWorker) Stop(jobID string, options worker.StopOptions) error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	w.stopped[jobID] = true
+	return nil
+}
+
+func (w *fakeWorker) Query(jobID string) (worker.Status, error) {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	switch {
+	case w.names[jobID] == "true":
+		return worker.Status{State: worker.StateSucceeded, Exited: true}, nil
+	case w.names[jobID] == "false":
+		return worker.Status{State: worker.StateFailed, Exited: true, ExitCode: 1}, nil
+	case w.stopped[jobID]:
+		return worker.Status{State: worker.StateKilled, ExitCode: -1}, nil
+	}
+	return worker.Status{State: worker.StateRunning}, nil
+}
+
+func (w *fakeWorker) isStopped(jobID string) bool {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	return w.stopped[jobID]
+}
+
+func (w *fakeWorker) startedNames() []string {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	return append([]string(nil), w.started...)
+}
+
+func step(name, program string, after ...Dependency) Step {
+	return Step{Name: name, Command: worker.Command{Name: program}, After: after}
+}
+
+func waitFinished(t *testing.T, e Engine, workflowID string) Workflow {
+	for i := 0; i < 20; i++ {
+		wf, err := e.Query(workflowID)
+		require.NoError(t, err)
+		if wf.State != StateRunning {
+			return wf
+		}
+		time.Sleep(pollInterval)
+	}
+	t.Fatal("the workflow isn't finished")
+	return Workflow{}
+}
+
+func stepStates(wf Workflow) map[string]StepState {
+	states := make(map[string]StepState)
+	for _, step := range wf.Steps {
+		states[step.Name] = step.State
+	}
+	return states
+}
+
+func TestWorkflowConditions(t *testing.T) {
+	w := newFakeWorker()
+	e := NewEngine(w)
+	defer e.Close()
+	id, err := e.Create(Spec{Steps: []Step{
+		step("build", "true"),
+		step("test", "false", Dependency{Step: "build"}),
+		step("deploy", "true", Dependency{Step: "test"}),
+		step("notify", "true", Dependency{Step: "test", Condition: ConditionFailure}),
+		step("cleanup", "true", Dependency{Step: "deploy", Condition: ConditionAlways}),
+	}})
+	require.NoError(t, err)
+
+	wf := waitFinished(t, e, id)
+
+	assert.Equal(t, StateFailed, wf.State)
+	assert.Equal(t, map[string]StepState{
+		"build":   StepSucceeded,
+		"test":    StepFailed,
+		"deploy":  StepSkipped,
+		"notify":  StepSucceeded,
+		"cleanup": StepSucceeded,
+	}, stepStates(wf))
+	assert.Equal(t, []string{"true", "false", "true", "true"}, w.startedNames())
+}
+
+func TestWorkflowSucceeded(t *testing.T) {
+	e := NewEngine(newFakeWorker())
+	defer e.Close()
+	id, err := e.Create(Spec{Steps: []Step{
+		step("a", "true"),
+		step("b", "true", Dependency{Step: "a"}),
+		step("c", "true", Dependency{Step: "a"}, Dependency{Step: "b"}),
+	}})
+	require.NoError(t, err)
+
+	wf := waitFinished(t, e, id)
+
+	assert.Equal(t, StateSucceeded, wf.State)
+	for _, step := range wf.Steps {
+		assert.NotEmpty(t, step.JobID)
+	}
+}
+
+func TestWorkflowStop(t *testing.T) {
+	w := newFakeWorker()
+	e := NewEngine(w)
+	defer e.Close()
+	id, err := e.Create(Spec{Steps: []Step{
+		step("serve", "sleep"),
+		step("after", "true", Dependency{Step: "serve"}),
+	}})
+	require.NoError(t, err)
+
+	require.NoError(t, e.Stop(id))
+
+	wf, err := e.Query(id)
+	require.NoError(t, err)
+	assert.Equal(t, StateCancelled, wf.State)
+	assert.Equal(t, map[string]StepState{"serve": StepCancelled, "after": StepCancelled}, stepStates(wf))
+	assert.True(t, w.stopped[wf.Steps[0].JobID])
+	assert.Error(t, e.Stop(id))
+}
+
+func TestWorkflowListWhileStarting(t *testing.T) {
+	w := newFakeWorker()
+	w.delay = time.Millisecond * 500
+	e := NewEngine(w)
+	defer e.Close()
+	created := make(chan string)
+	go func() {
+		id, err := e.Create(Spec{Steps: []Step{
+			step("a", "true"),
+			step("b", "true", Dependency{Step: "a"}),
+		}})
+		assert.NoError(t, err)
+		created <- id
+	}()
+
+	// the workflows are listed while the jobs are started
+	var slowest time.Duration
+	for end := time.Now().Add(time.Millisecond * 1200); time.Now().Before(end); {
+		start := time.Now()
+		e.List()
+		if elapsed := time.Since(start); elapsed > slowest {
+			slowest = elapsed
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+	assert.Less(t, int64(slowest), int64(time.Millisecond*100))
+	wf := waitFinished(t, e, <-created)
+	assert.Equal(t, StateSucceeded, wf.State)
+}
+
+func TestWorkflowStopWhileStarting(t *testing.T) {
+	w := newFakeWorker()
+	e := NewEngine(w)
+	defer e.Close()
+	id, err := e.Create(Spec{Steps: []Step{
+		step("build", "true"),
+		step("serve", "sleep", Dependency{Step: "build"}),
+	}})
+	require.NoError(t, err)
+	w.mtx.Lock()
+	w.delay = time.Millisecond * 500
+	w.mtx.Unlock()
+
+	// the workflow is stopped while the serve job is started
+	time.Sleep(pollInterval + time.Millisecond*100)
+	require.NoError(t, e.Stop(id))
+	time.Sleep(time.Second)
+
+	wf, err := e.Query(id)
+	require.NoError(t, err)
+	assert.Equal(t, StateCancelled, wf.State)
+	assert.Equal(t, map[string]StepState{"build": StepSucceeded, "serve": StepCancelled}, stepStates(wf))
+	require.NotEmpty(t, wf.Steps[1].JobID)
+	assert.True(t, w.isStopped(wf.Steps[1].JobID))
+}
+
+func TestWorkflowInvalid(t *testing.T) {
+	e := NewEngine(newFakeWorker())
+	defer e.Close()
+
+	_, err := e.Create(Spec{})
+	assert.Error(t, err)
+	_, err = e.Create(Spec{Steps: []Step{step("a", "true"), step("a", "true")}})
+	assert.Error(t, err)
+	_, err = e.Create(Spec{Steps: []Step{step("a", "true", Dependency{Step: "unknown"})}})
+	assert.Error(t, err)
+	_, err = e.Create(Spec{Steps: []Step{
+		step("a", "true", Dependency{Step: "c"}),
+		step("b", "true", Dependency{Step: "a"}),
+		step("c", "true", Dependency{Step: "b"}),
+	}})
+	assert.Error(t, err)
+	assert.Empty(t, e.List())
+}